@@ -25,32 +25,48 @@
 package cli
 
 import (
+	"github.com/temporalio/tctl-kit/pkg/flags"
 	"github.com/urfave/cli/v2"
 )
 
 func newActivityCommands() []*cli.Command {
 	return []*cli.Command{
 		{
-			Name:  "complete",
-			Usage: "Complete an activity",
-			Flags: []cli.Flag{
+			Name:        "list",
+			Usage:       "List pending activities of open Workflow Executions matching a Query",
+			Description: "Describes every matching open Workflow Execution and prints their pending activities, most retried first",
+			Flags: append([]cli.Flag{
 				&cli.StringFlag{
-					Name:     FlagWorkflowID,
-					Aliases:  FlagWorkflowIDAlias,
-					Usage:    "Workflow Id",
-					Required: true,
+					Name:    FlagQuery,
+					Aliases: FlagQueryAlias,
+					Usage:   FlagQueryUsage,
 				},
-				&cli.StringFlag{
-					Name:     FlagRunID,
-					Aliases:  FlagRunIDAlias,
-					Usage:    "Run Id",
-					Required: true,
+				&cli.IntFlag{
+					Name:  FlagMinAttempt,
+					Usage: "Only show activities on at least this attempt",
 				},
 				&cli.StringFlag{
-					Name:     FlagActivityID,
-					Usage:    "The Activity Id to complete",
-					Required: true,
+					Name:  FlagStaleHeartbeat,
+					Usage: "Only show started activities that have not heartbeated for at least this long, e.g. 10m",
+				},
+				&cli.IntFlag{
+					Name:  FlagConcurrency,
+					Usage: "Number of Workflow Executions described at once",
+					Value: 10,
 				},
+				&cli.Float64Flag{
+					Name:  FlagRPS,
+					Usage: "Maximum Workflow Executions described per second, unlimited if not set",
+				},
+			}, flags.FlagsForRendering...),
+			Action: func(c *cli.Context) error {
+				return ListActivities(c)
+			},
+		},
+		{
+			Name:  "complete",
+			Usage: "Complete an activity",
+			Flags: append(append(flagsForActivityExecution("The Activity Id to complete"),
 				&cli.StringFlag{
 					Name:     FlagResult,
 					Usage:    "Set the result value of completion",
@@ -61,7 +77,7 @@ func newActivityCommands() []*cli.Command {
 					Usage:    "Specify operator's identity",
 					Required: true,
 				},
-			},
+			), flagsForPayloadInput...),
 			Action: func(c *cli.Context) error {
 				return CompleteActivity(c)
 			},
@@ -69,43 +85,92 @@ func newActivityCommands() []*cli.Command {
 		{
 			Name:  "fail",
 			Usage: "Fail an activity",
-			Flags: []cli.Flag{
+			Flags: append(flagsForActivityExecution("The Activity Id to fail"),
 				&cli.StringFlag{
-					Name:     FlagWorkflowID,
-					Aliases:  FlagWorkflowIDAlias,
-					Usage:    "Workflow Id",
+					Name:     FlagReason,
+					Usage:    "Reason to fail the Activity",
 					Required: true,
 				},
 				&cli.StringFlag{
-					Name:     FlagRunID,
-					Aliases:  FlagRunIDAlias,
-					Usage:    "Run Id",
+					Name:     FlagDetail,
+					Usage:    "Detail to fail the Activity",
 					Required: true,
 				},
 				&cli.StringFlag{
-					Name:     FlagActivityID,
-					Usage:    "The Activity Id to fail",
+					Name:     FlagIdentity,
+					Usage:    "Specify operator's identity",
 					Required: true,
 				},
+				&cli.BoolFlag{
+					Name:  FlagRetryable,
+					Usage: "Allow the Activity to be retried according to its retry policy",
+				},
 				&cli.StringFlag{
-					Name:     FlagReason,
-					Usage:    "Reason to fail the Activity",
-					Required: true,
+					Name:  FlagFailureType,
+					Usage: "Application failure type, matched against the non-retryable error types of the retry policy",
 				},
+			),
+			Action: func(c *cli.Context) error {
+				return FailActivity(c)
+			},
+		},
+		{
+			Name:  "cancel",
+			Usage: "Report an activity as canceled",
+			Flags: append(flagsForActivityExecution("The Activity Id to cancel"),
 				&cli.StringFlag{
-					Name:     FlagDetail,
-					Usage:    "Detail to fail the Activity",
-					Required: true,
+					Name:  FlagDetail,
+					Usage: "Cancellation details, JSON or plain text",
 				},
 				&cli.StringFlag{
 					Name:     FlagIdentity,
 					Usage:    "Specify operator's identity",
 					Required: true,
 				},
+			),
+			Action: func(c *cli.Context) error {
+				return CancelActivity(c)
 			},
+		},
+		{
+			Name:  "heartbeat",
+			Usage: "Record a heartbeat for an activity",
+			Flags: append(flagsForActivityExecution("The Activity Id to heartbeat"),
+				&cli.StringFlag{
+					Name:  FlagDetail,
+					Usage: "Heartbeat details, JSON or plain text",
+				},
+				&cli.StringFlag{
+					Name:     FlagIdentity,
+					Usage:    "Specify operator's identity",
+					Required: true,
+				},
+			),
 			Action: func(c *cli.Context) error {
-				return FailActivity(c)
+				return HeartbeatActivity(c)
 			},
 		},
 	}
 }
+
+func flagsForActivityExecution(activityIDUsage string) []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:     FlagWorkflowID,
+			Aliases:  FlagWorkflowIDAlias,
+			Usage:    "Workflow Id",
+			Required: true,
+		},
+		&cli.StringFlag{
+			Name:     FlagRunID,
+			Aliases:  FlagRunIDAlias,
+			Usage:    "Run Id",
+			Required: true,
+		},
+		&cli.StringFlag{
+			Name:     FlagActivityID,
+			Usage:    activityIDUsage,
+			Required: true,
+		},
+	}
+}
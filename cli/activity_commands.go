@@ -25,10 +25,12 @@
 package cli
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/temporalio/tctl-kit/pkg/color"
 	"github.com/urfave/cli/v2"
+	commonpb "go.temporal.io/api/common/v1"
 	failurepb "go.temporal.io/api/failure/v1"
 	"go.temporal.io/api/workflowservice/v1"
 )
@@ -48,9 +50,17 @@ func CompleteActivity(c *cli.Context) error {
 	ctx, cancel := newContext(c)
 	defer cancel()
 
-	// TODO: This should use customDataConverter once the plugin interface
-	// supports the full DataConverter API.
-	resultPayloads, _ := defaultDataConverter().ToPayloads(result)
+	values := []interface{}{result}
+	if isRawPayloadInput(c) {
+		values, err = rawPayloadInputs(c, [][]byte{[]byte(result)})
+		if err != nil {
+			return err
+		}
+	}
+	resultPayloads, err := customDataConverter().ToPayloads(values...)
+	if err != nil {
+		return fmt.Errorf("unable to encode result: %w", err)
+	}
 
 	frontendClient := cFactory.FrontendClient(c)
 	_, err = frontendClient.RespondActivityTaskCompletedById(ctx, &workflowservice.RespondActivityTaskCompletedByIdRequest{
@@ -89,9 +99,10 @@ func FailActivity(c *cli.Context) error {
 	ctx, cancel := newContext(c)
 	defer cancel()
 
-	// TODO: This should use customDataConverter once the plugin interface
-	// supports the full DataConverter API.
-	detailsPayloads, _ := defaultDataConverter().ToPayloads(detail)
+	detailsPayloads, err := customDataConverter().ToPayloads(detail)
+	if err != nil {
+		return fmt.Errorf("unable to encode details: %w", err)
+	}
 
 	frontendClient := cFactory.FrontendClient(c)
 	_, err = frontendClient.RespondActivityTaskFailedById(ctx, &workflowservice.RespondActivityTaskFailedByIdRequest{
@@ -103,7 +114,8 @@ func FailActivity(c *cli.Context) error {
 			Message: reason,
 			Source:  "CLI",
 			FailureInfo: &failurepb.Failure_ApplicationFailureInfo{ApplicationFailureInfo: &failurepb.ApplicationFailureInfo{
-				NonRetryable: true,
+				Type:         c.String(FlagFailureType),
+				NonRetryable: !c.Bool(FlagRetryable),
 				Details:      detailsPayloads,
 			}},
 		},
@@ -117,3 +129,86 @@ func FailActivity(c *cli.Context) error {
 		return nil
 	}
 }
+
+// CancelActivity reports an activity as canceled
+func CancelActivity(c *cli.Context) error {
+	namespace, err := requiredFlag(c, FlagNamespace)
+	if err != nil {
+		return err
+	}
+
+	detailsPayloads, err := activityDetailsPayloads(c.String(FlagDetail))
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+	frontendClient := cFactory.FrontendClient(c)
+	_, err = frontendClient.RespondActivityTaskCanceledById(ctx, &workflowservice.RespondActivityTaskCanceledByIdRequest{
+		Namespace:  namespace,
+		WorkflowId: c.String(FlagWorkflowID),
+		RunId:      c.String(FlagRunID),
+		ActivityId: c.String(FlagActivityID),
+		Details:    detailsPayloads,
+		Identity:   c.String(FlagIdentity),
+	})
+	if err != nil {
+		return fmt.Errorf("unable to cancel Activity: %w", err)
+	}
+	fmt.Println(color.Green(c, "Activity was canceled"))
+	return nil
+}
+
+// HeartbeatActivity records a heartbeat for an activity
+func HeartbeatActivity(c *cli.Context) error {
+	namespace, err := requiredFlag(c, FlagNamespace)
+	if err != nil {
+		return err
+	}
+
+	detailsPayloads, err := activityDetailsPayloads(c.String(FlagDetail))
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+	frontendClient := cFactory.FrontendClient(c)
+	resp, err := frontendClient.RecordActivityTaskHeartbeatById(ctx, &workflowservice.RecordActivityTaskHeartbeatByIdRequest{
+		Namespace:  namespace,
+		WorkflowId: c.String(FlagWorkflowID),
+		RunId:      c.String(FlagRunID),
+		ActivityId: c.String(FlagActivityID),
+		Details:    detailsPayloads,
+		Identity:   c.String(FlagIdentity),
+	})
+	if err != nil {
+		return fmt.Errorf("unable to heartbeat Activity: %w", err)
+	}
+	fmt.Println(color.Green(c, "Activity heartbeat was recorded"))
+	if resp.GetCancelRequested() {
+		fmt.Println(color.Yellow(c, "Cancellation of the Activity has been requested"))
+	}
+	return nil
+}
+
+// activityDetailsPayloads encodes the details given on the command line. Valid JSON is decoded first so that
+// objects and numbers keep their type, anything else is sent as a string.
+func activityDetailsPayloads(detail string) (*commonpb.Payloads, error) {
+	if detail == "" {
+		return nil, nil
+	}
+
+	var value interface{} = detail
+	var decoded interface{}
+	if err := json.Unmarshal([]byte(detail), &decoded); err == nil {
+		value = decoded
+	}
+
+	p, err := customDataConverter().ToPayloads(value)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode details: %w", err)
+	}
+	return p, nil
+}
@@ -0,0 +1,192 @@
+// The MIT License
+//
+// Copyright (c) 2022 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/temporalio/tctl-kit/pkg/color"
+	"github.com/temporalio/tctl-kit/pkg/output"
+	"github.com/urfave/cli/v2"
+	"go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+	workflowpb "go.temporal.io/api/workflow/v1"
+	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/server/common/primitives/timestamp"
+)
+
+// pendingActivityRow is a pending activity of a workflow execution, flattened for printing
+type pendingActivityRow struct {
+	WorkflowId         string
+	RunId              string
+	ActivityId         string
+	ActivityType       string
+	State              enums.PendingActivityState
+	Attempt            int32
+	MaximumAttempts    int32
+	LastFailure        string
+	LastHeartbeatAge   time.Duration
+	LastStartedTime    *time.Time
+	LastWorkerIdentity string
+	// TaskQueue is the task queue of the workflow, the pending activity info does not carry its own
+	TaskQueue string
+}
+
+// ListActivities lists pending activities of all open workflow executions matching a query
+func ListActivities(c *cli.Context) error {
+	namespace, err := requiredFlag(c, FlagNamespace)
+	if err != nil {
+		return err
+	}
+	var staleHeartbeat time.Duration
+	if c.IsSet(FlagStaleHeartbeat) {
+		if staleHeartbeat, err = timestamp.ParseDuration(c.String(FlagStaleHeartbeat)); err != nil {
+			return fmt.Errorf("unable to parse %s: %w", FlagStaleHeartbeat, err)
+		}
+	}
+	minAttempt := int32(c.Int(FlagMinAttempt))
+	sdkClient, err := getSDKClient(c)
+	if err != nil {
+		return err
+	}
+	frontendClient := cFactory.FrontendClient(c)
+
+	query := "ExecutionStatus = 'Running'"
+	if c.String(FlagQuery) != "" {
+		query = fmt.Sprintf("(%s) AND %s", c.String(FlagQuery), query)
+	}
+
+	now := time.Now()
+	var lock sync.Mutex
+	var rows []*pendingActivityRow
+	var failures []string
+	var npt []byte
+	for {
+		var items []interface{}
+		items, npt, err = listWorkflows(c, sdkClient, npt, query)
+		if err != nil {
+			return err
+		}
+
+		runConcurrently(len(items), c.Int(FlagConcurrency), c.Float64(FlagRPS), func(i int) {
+			info := items[i].(*workflowpb.WorkflowExecutionInfo)
+			activities, err := describePendingActivities(c, frontendClient, namespace, info, now)
+			lock.Lock()
+			defer lock.Unlock()
+			if err != nil {
+				failures = append(failures, fmt.Sprintf("%s/%s: %v", info.GetExecution().GetWorkflowId(), info.GetExecution().GetRunId(), err))
+				return
+			}
+			for _, a := range activities {
+				if a.Attempt < minAttempt {
+					continue
+				}
+				if staleHeartbeat > 0 && (a.State != enums.PENDING_ACTIVITY_STATE_STARTED || a.LastHeartbeatAge < staleHeartbeat) {
+					continue
+				}
+				rows = append(rows, a)
+			}
+		})
+
+		if len(npt) == 0 {
+			break
+		}
+	}
+
+	// activities retrying the most come first
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].Attempt != rows[j].Attempt {
+			return rows[i].Attempt > rows[j].Attempt
+		}
+		if rows[i].WorkflowId != rows[j].WorkflowId {
+			return rows[i].WorkflowId < rows[j].WorkflowId
+		}
+		return rows[i].ActivityId < rows[j].ActivityId
+	})
+	items := make([]interface{}, len(rows))
+	for i, r := range rows {
+		items[i] = r
+	}
+	err = output.PrintItems(c, items, &output.PrintOptions{
+		Fields:     []string{"WorkflowId", "ActivityId", "ActivityType", "State", "Attempt", "LastFailure", "LastHeartbeatAge", "TaskQueue"},
+		FieldsLong: []string{"RunId", "MaximumAttempts", "LastStartedTime", "LastWorkerIdentity"},
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, f := range failures {
+		fmt.Println(color.Red(c, "Failed to describe %s", f))
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("unable to describe %d workflow executions", len(failures))
+	}
+	return nil
+}
+
+// describePendingActivities returns the pending activities of a workflow execution.
+// Executions that closed after being listed have no pending activities.
+func describePendingActivities(c *cli.Context, frontendClient workflowservice.WorkflowServiceClient, namespace string, info *workflowpb.WorkflowExecutionInfo, now time.Time) ([]*pendingActivityRow, error) {
+	ctx, cancel := newContext(c)
+	defer cancel()
+	resp, err := frontendClient.DescribeWorkflowExecution(ctx, &workflowservice.DescribeWorkflowExecutionRequest{
+		Namespace: namespace,
+		Execution: info.GetExecution(),
+	})
+	if err != nil {
+		if _, ok := err.(*serviceerror.NotFound); ok {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var rows []*pendingActivityRow
+	for _, a := range resp.GetPendingActivities() {
+		row := &pendingActivityRow{
+			WorkflowId:         info.GetExecution().GetWorkflowId(),
+			RunId:              info.GetExecution().GetRunId(),
+			ActivityId:         a.GetActivityId(),
+			ActivityType:       a.GetActivityType().GetName(),
+			State:              a.GetState(),
+			Attempt:            a.GetAttempt(),
+			MaximumAttempts:    a.GetMaximumAttempts(),
+			LastFailure:        a.GetLastFailure().GetMessage(),
+			LastStartedTime:    a.GetLastStartedTime(),
+			LastWorkerIdentity: a.GetLastWorkerIdentity(),
+			TaskQueue:          info.GetTaskQueue(),
+		}
+		// an activity that never heartbeated is as stale as its last start
+		if lastHeartbeat := a.GetLastHeartbeatTime(); lastHeartbeat != nil {
+			row.LastHeartbeatAge = now.Sub(*lastHeartbeat).Truncate(time.Second)
+		} else if lastStarted := a.GetLastStartedTime(); lastStarted != nil {
+			row.LastHeartbeatAge = now.Sub(*lastStarted).Truncate(time.Second)
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
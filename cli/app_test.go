@@ -25,7 +25,11 @@
 package cli
 
 import (
+	"context"
 	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -39,14 +43,19 @@ import (
 	historypb "go.temporal.io/api/history/v1"
 	"go.temporal.io/api/operatorservice/v1"
 	"go.temporal.io/api/operatorservicemock/v1"
+	"go.temporal.io/api/serviceerror"
 	taskqueuepb "go.temporal.io/api/taskqueue/v1"
+	workflowpb "go.temporal.io/api/workflow/v1"
 	"go.temporal.io/api/workflowservice/v1"
 	"go.temporal.io/api/workflowservicemock/v1"
 	sdkclient "go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/converter"
 	sdkmocks "go.temporal.io/sdk/mocks"
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 
 	"go.temporal.io/server/common/primitives/timestamp"
+
+	"github.com/temporalio/tctl/cli/dataconverter"
 )
 
 type cliAppSuite struct {
@@ -165,9 +174,34 @@ func (s *cliAppSuite) TestAcceptStringSliceArgsWithCommas() {
 
 func (s *cliAppSuite) TestDescribeTaskQueue() {
 	s.sdkClient.On("DescribeTaskQueue", mock.Anything, mock.Anything, mock.Anything).Return(describeTaskQueueResponse, nil).Once()
-	err := s.app.Run([]string{"", "--namespace", cliTestNamespace, "task-queue", "describe", "--task-queue", "test-taskQueue"})
+	var err error
+	out := s.captureOutput(func() {
+		err = s.app.Run([]string{"", "--namespace", cliTestNamespace, "task-queue", "describe", "--task-queue", "test-taskQueue", "-o", "json"})
+	})
+	s.Nil(err)
+	s.sdkClient.AssertExpectations(s.T())
+
+	// without the new flags the pollers keep the PollerInfo shape
+	var pollers []map[string]interface{}
+	s.NoError(json.Unmarshal([]byte(out), &pollers), out)
+	s.Len(pollers, 1)
+	s.Equal("tester", pollers[0]["identity"])
+	s.NotContains(pollers[0], "TaskQueueType")
+}
+
+func (s *cliAppSuite) TestDescribeTaskQueue_Watch() {
+	defer func(unit time.Duration) { taskQueueWatchUnit = unit }(taskQueueWatchUnit)
+	taskQueueWatchUnit = time.Millisecond
+
+	s.sdkClient.On("DescribeTaskQueue", mock.Anything, "test-taskQueue", enumspb.TASK_QUEUE_TYPE_WORKFLOW).Return(describeTaskQueueResponse, nil).Once()
+	s.sdkClient.On("DescribeTaskQueue", mock.Anything, "test-taskQueue", enumspb.TASK_QUEUE_TYPE_WORKFLOW).Return(&workflowservice.DescribeTaskQueueResponse{}, nil).Once()
+	var err error
+	out := s.captureOutput(func() {
+		err = s.app.Run([]string{"", "--namespace", cliTestNamespace, "task-queue", "describe", "--task-queue", "test-taskQueue", "--watch", "1", "--count", "2"})
+	})
 	s.Nil(err)
 	s.sdkClient.AssertExpectations(s.T())
+	s.Contains(out, "poller tester is gone")
 }
 
 func (s *cliAppSuite) TestDescribeTaskQueue_Activity() {
@@ -177,6 +211,298 @@ func (s *cliAppSuite) TestDescribeTaskQueue_Activity() {
 	s.sdkClient.AssertExpectations(s.T())
 }
 
+func (s *cliAppSuite) TestDescribeTaskQueue_AllTypes() {
+	s.sdkClient.On("DescribeTaskQueue", mock.Anything, "test-taskQueue", enumspb.TASK_QUEUE_TYPE_WORKFLOW).Return(describeTaskQueueResponse, nil).Once()
+	s.sdkClient.On("DescribeTaskQueue", mock.Anything, "test-taskQueue", enumspb.TASK_QUEUE_TYPE_ACTIVITY).Return(describeTaskQueueResponse, nil).Once()
+	err := s.app.Run([]string{"", "--namespace", cliTestNamespace, "task-queue", "describe", "--task-queue", "test-taskQueue", "--all-types"})
+	s.Nil(err)
+	s.sdkClient.AssertExpectations(s.T())
+}
+
+func (s *cliAppSuite) TestDescribeTaskQueue_IncludeStatus() {
+	s.frontendClient.EXPECT().DescribeTaskQueue(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, req *workflowservice.DescribeTaskQueueRequest, _ ...interface{}) (*workflowservice.DescribeTaskQueueResponse, error) {
+			s.True(req.GetIncludeTaskQueueStatus())
+			s.Equal(enumspb.TASK_QUEUE_TYPE_ACTIVITY, req.GetTaskQueueType())
+			return &workflowservice.DescribeTaskQueueResponse{
+				Pollers:         describeTaskQueueResponse.Pollers,
+				TaskQueueStatus: &taskqueuepb.TaskQueueStatus{BacklogCountHint: 120, ReadLevel: 300, AckLevel: 180},
+			}, nil
+		})
+	err := s.app.Run([]string{"", "--namespace", cliTestNamespace, "task-queue", "describe", "--task-queue", "test-taskQueue", "--task-queue-type", "activity", "--include-status"})
+	s.Nil(err)
+}
+
+func (s *cliAppSuite) TestGoneTaskQueuePollers() {
+	previous := []*taskQueuePoller{
+		{TaskQueueType: enumspb.TASK_QUEUE_TYPE_WORKFLOW, Identity: "worker-1"},
+		{TaskQueueType: enumspb.TASK_QUEUE_TYPE_ACTIVITY, Identity: "worker-1"},
+		{TaskQueueType: enumspb.TASK_QUEUE_TYPE_WORKFLOW, Identity: "worker-2"},
+	}
+	current := []*taskQueuePoller{
+		{TaskQueueType: enumspb.TASK_QUEUE_TYPE_WORKFLOW, Identity: "worker-1"},
+		{TaskQueueType: enumspb.TASK_QUEUE_TYPE_WORKFLOW, Identity: "worker-3"},
+	}
+	gone := goneTaskQueuePollers(previous, current)
+	s.Equal([]*taskQueuePoller{previous[1], previous[2]}, gone)
+	s.Empty(goneTaskQueuePollers(nil, current))
+}
+
+func (s *cliAppSuite) TestCheckTaskQueues() {
+	s.frontendClient.EXPECT().DescribeTaskQueue(gomock.Any(), gomock.Any()).Return(&workflowservice.DescribeTaskQueueResponse{
+		Pollers:         describeTaskQueueResponse.Pollers,
+		TaskQueueStatus: &taskqueuepb.TaskQueueStatus{BacklogCountHint: 10},
+	}, nil).Times(4)
+	err := s.app.Run([]string{"", "--namespace", cliTestNamespace, "task-queue", "check", "--task-queue", "orders,payments",
+		"--max-last-access", "1m", "--max-backlog", "1000"})
+	s.Nil(err)
+}
+
+func (s *cliAppSuite) TestCheckTaskQueues_Unhealthy() {
+	s.sdkClient.On("DescribeTaskQueue", mock.Anything, "orders", enumspb.TASK_QUEUE_TYPE_WORKFLOW).Return(describeTaskQueueResponse, nil).Once()
+	s.sdkClient.On("DescribeTaskQueue", mock.Anything, "orders", enumspb.TASK_QUEUE_TYPE_ACTIVITY).Return(&workflowservice.DescribeTaskQueueResponse{}, nil).Once()
+	errorCode := s.RunWithExitCode([]string{"", "--namespace", cliTestNamespace, "task-queue", "check", "--task-queue", "orders"})
+	s.Equal(1, errorCode)
+	s.sdkClient.AssertExpectations(s.T())
+}
+
+func (s *cliAppSuite) TestCheckTaskQueueHealth() {
+	now := time.Now()
+	resp := &workflowservice.DescribeTaskQueueResponse{
+		Pollers: []*taskqueuepb.PollerInfo{
+			{Identity: "worker-1", LastAccessTime: timestamp.TimePtr(now.Add(-10 * time.Second))},
+			{Identity: "worker-2", LastAccessTime: timestamp.TimePtr(now.Add(-3 * time.Minute))},
+		},
+		TaskQueueStatus: &taskqueuepb.TaskQueueStatus{BacklogCountHint: 1500},
+	}
+
+	result := &taskQueueHealth{}
+	checkTaskQueueHealth(result, resp, taskQueueThresholds{minPollers: 2, maxLastAccess: time.Minute, maxBacklog: 1000}, now)
+	s.False(result.Healthy)
+	s.Equal(1, result.Pollers)
+	s.Equal(1, result.StalePollers)
+	s.Equal("1 pollers, expected at least 2; backlog of 1500, expected at most 1000", result.Problems)
+
+	result = &taskQueueHealth{}
+	checkTaskQueueHealth(result, resp, taskQueueThresholds{minPollers: 2, maxBacklog: -1}, now)
+	s.True(result.Healthy)
+	s.Equal(2, result.Pollers)
+	s.Empty(result.Problems)
+}
+
+func (s *cliAppSuite) TestListTaskQueues() {
+	s.sdkClient.On("ListWorkflow", mock.Anything, mock.Anything).Return(&workflowservice.ListWorkflowExecutionsResponse{
+		Executions: []*workflowpb.WorkflowExecutionInfo{
+			{TaskQueue: "orders", Status: enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING},
+			{TaskQueue: "orders", Status: enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED},
+			{TaskQueue: "payments", Status: enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING},
+		},
+	}, nil).Once()
+	s.sdkClient.On("DescribeTaskQueue", mock.Anything, "orders", mock.Anything).Return(describeTaskQueueResponse, nil).Twice()
+	s.sdkClient.On("DescribeTaskQueue", mock.Anything, "payments", mock.Anything).Return(&workflowservice.DescribeTaskQueueResponse{}, nil).Twice()
+
+	err := s.app.Run([]string{"", "--namespace", cliTestNamespace, "task-queue", "list", "--with-pollers"})
+	s.Nil(err)
+	s.sdkClient.AssertExpectations(s.T())
+}
+
+func (s *cliAppSuite) TestCountTaskQueueUsage() {
+	usages := make(map[string]*taskQueueUsage)
+	countTaskQueueUsage(usages, &workflowpb.WorkflowExecutionInfo{TaskQueue: "orders", Status: enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING})
+	countTaskQueueUsage(usages, &workflowpb.WorkflowExecutionInfo{TaskQueue: "orders", Status: enumspb.WORKFLOW_EXECUTION_STATUS_FAILED})
+	countTaskQueueUsage(usages, &workflowpb.WorkflowExecutionInfo{TaskQueue: "orders", Status: enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING})
+	s.Equal(map[string]*taskQueueUsage{
+		"orders": {TaskQueue: "orders", OpenWorkflows: 2, ClosedWorkflows: 1},
+	}, usages)
+}
+
+func (s *cliAppSuite) TestUpdateBuildIDs() {
+	s.frontendClient.EXPECT().UpdateWorkerBuildIdOrdering(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, req *workflowservice.UpdateWorkerBuildIdOrderingRequest, _ ...interface{}) (*workflowservice.UpdateWorkerBuildIdOrderingResponse, error) {
+			s.Equal("test-taskQueue", req.GetTaskQueue())
+			s.Equal("2.1", req.GetVersionId().GetWorkerBuildId())
+			s.Equal("2.0", req.GetPreviousCompatible().GetWorkerBuildId())
+			s.False(req.GetBecomeDefault())
+			return &workflowservice.UpdateWorkerBuildIdOrderingResponse{}, nil
+		})
+	err := s.app.Run([]string{"", "--namespace", cliTestNamespace, "task-queue", "update-build-ids", "add-new-compatible", "--task-queue", "test-taskQueue",
+		"--build-id", "2.1", "--existing-compatible-build-id", "2.0"})
+	s.Nil(err)
+
+	s.frontendClient.EXPECT().UpdateWorkerBuildIdOrdering(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, req *workflowservice.UpdateWorkerBuildIdOrderingRequest, _ ...interface{}) (*workflowservice.UpdateWorkerBuildIdOrderingResponse, error) {
+			s.Equal("3.0", req.GetVersionId().GetWorkerBuildId())
+			s.Nil(req.GetPreviousCompatible())
+			s.True(req.GetBecomeDefault())
+			return &workflowservice.UpdateWorkerBuildIdOrderingResponse{}, nil
+		})
+	err = s.app.Run([]string{"", "--namespace", cliTestNamespace, "task-queue", "update-build-ids", "add-new-default", "--task-queue", "test-taskQueue", "--build-id", "3.0"})
+	s.Nil(err)
+}
+
+func (s *cliAppSuite) TestBuildIDSets() {
+	v1 := &taskqueuepb.VersionIdNode{Version: &taskqueuepb.VersionId{WorkerBuildId: "1.0"}}
+	v11 := &taskqueuepb.VersionIdNode{Version: &taskqueuepb.VersionId{WorkerBuildId: "1.1"}, PreviousCompatible: v1}
+	v2 := &taskqueuepb.VersionIdNode{Version: &taskqueuepb.VersionId{WorkerBuildId: "2.0"}, PreviousIncompatible: v11}
+	v21 := &taskqueuepb.VersionIdNode{Version: &taskqueuepb.VersionId{WorkerBuildId: "2.1"}, PreviousCompatible: v2}
+
+	sets := buildIDSets(&workflowservice.GetWorkerBuildIdOrderingResponse{
+		CurrentDefault:   v21,
+		CompatibleLeaves: []*taskqueuepb.VersionIdNode{v21, v11},
+	})
+	s.Equal([]*buildIDSet{
+		{BuildIds: []string{"2.1", "2.0"}, IsDefault: true},
+		{BuildIds: []string{"1.1", "1.0"}},
+	}, sets)
+	s.Empty(buildIDSets(&workflowservice.GetWorkerBuildIdOrderingResponse{}))
+}
+
+func (s *cliAppSuite) TestGetBuildIDReachability() {
+	s.frontendClient.EXPECT().GetWorkerBuildIdOrdering(gomock.Any(), gomock.Any()).Return(&workflowservice.GetWorkerBuildIdOrderingResponse{
+		CurrentDefault: &taskqueuepb.VersionIdNode{Version: &taskqueuepb.VersionId{WorkerBuildId: "2.0"}},
+	}, nil)
+	s.sdkClient.On("CountWorkflow", mock.Anything, &workflowservice.CountWorkflowExecutionsRequest{
+		Namespace: cliTestNamespace,
+		Query:     "TaskQueue = 'test-taskQueue' AND BinaryChecksums = '1.0' AND ExecutionStatus = 'Running'",
+	}).Return(&workflowservice.CountWorkflowExecutionsResponse{Count: 3}, nil).Once()
+	s.sdkClient.On("CountWorkflow", mock.Anything, &workflowservice.CountWorkflowExecutionsRequest{
+		Namespace: cliTestNamespace,
+		Query:     "TaskQueue = 'test-taskQueue' AND BinaryChecksums = '1.0' AND ExecutionStatus != 'Running'",
+	}).Return(&workflowservice.CountWorkflowExecutionsResponse{Count: 40}, nil).Once()
+
+	err := s.app.Run([]string{"", "--namespace", cliTestNamespace, "task-queue", "get-build-id-reachability", "--task-queue", "test-taskQueue", "--build-id", "1.0"})
+	s.Nil(err)
+	s.sdkClient.AssertExpectations(s.T())
+}
+
+func (s *cliAppSuite) TestGetBuildIDReachability_EscapesBuildID() {
+	s.frontendClient.EXPECT().GetWorkerBuildIdOrdering(gomock.Any(), gomock.Any()).Return(&workflowservice.GetWorkerBuildIdOrderingResponse{}, nil)
+	s.sdkClient.On("CountWorkflow", mock.Anything, &workflowservice.CountWorkflowExecutionsRequest{
+		Namespace: cliTestNamespace,
+		Query:     `TaskQueue = 'test-taskQueue' AND BinaryChecksums = 'it\'s' AND ExecutionStatus = 'Running'`,
+	}).Return(&workflowservice.CountWorkflowExecutionsResponse{}, nil).Once()
+	s.sdkClient.On("CountWorkflow", mock.Anything, &workflowservice.CountWorkflowExecutionsRequest{
+		Namespace: cliTestNamespace,
+		Query:     `TaskQueue = 'test-taskQueue' AND BinaryChecksums = 'it\'s' AND ExecutionStatus != 'Running'`,
+	}).Return(&workflowservice.CountWorkflowExecutionsResponse{}, nil).Once()
+
+	err := s.app.Run([]string{"", "--namespace", cliTestNamespace, "task-queue", "get-build-id-reachability", "--task-queue", "test-taskQueue", "--build-id", "it's"})
+	s.Nil(err)
+	s.sdkClient.AssertExpectations(s.T())
+}
+
+func (s *cliAppSuite) TestFailActivity_Retryable() {
+	s.frontendClient.EXPECT().RespondActivityTaskFailedById(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, req *workflowservice.RespondActivityTaskFailedByIdRequest, _ ...interface{}) (*workflowservice.RespondActivityTaskFailedByIdResponse, error) {
+			info := req.GetFailure().GetApplicationFailureInfo()
+			s.False(info.GetNonRetryable())
+			s.Equal("ApprovalRejected", info.GetType())
+			return &workflowservice.RespondActivityTaskFailedByIdResponse{}, nil
+		})
+	err := s.app.Run([]string{"", "--namespace", cliTestNamespace, "activity", "fail", "--workflow-id", "wid", "--run-id", "rid", "--activity-id", "aid",
+		"--reason", "rejected", "--detail", "by reviewer", "--identity", "operator", "--retryable", "--failure-type", "ApprovalRejected"})
+	s.Nil(err)
+}
+
+func (s *cliAppSuite) TestCompleteActivity_CustomDataConverter() {
+	defer dataconverter.SetCurrent(dataconverter.GetCurrent())
+	dataconverter.SetCurrent(converter.NewCodecDataConverter(dataconverter.GetCurrent(), converter.NewZlibCodec(converter.ZlibCodecOptions{AlwaysEncode: true})))
+
+	s.frontendClient.EXPECT().RespondActivityTaskCompletedById(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, req *workflowservice.RespondActivityTaskCompletedByIdRequest, _ ...interface{}) (*workflowservice.RespondActivityTaskCompletedByIdResponse, error) {
+			s.Equal("binary/zlib", string(req.GetResult().GetPayloads()[0].GetMetadata()["encoding"]))
+			return &workflowservice.RespondActivityTaskCompletedByIdResponse{}, nil
+		})
+	err := s.app.Run([]string{"", "--namespace", cliTestNamespace, "activity", "complete", "--workflow-id", "wid", "--run-id", "rid", "--activity-id", "aid",
+		"--result", "approved", "--identity", "operator"})
+	s.Nil(err)
+}
+
+func (s *cliAppSuite) TestCancelActivity() {
+	s.frontendClient.EXPECT().RespondActivityTaskCanceledById(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, req *workflowservice.RespondActivityTaskCanceledByIdRequest, _ ...interface{}) (*workflowservice.RespondActivityTaskCanceledByIdResponse, error) {
+			s.Equal("aid", req.GetActivityId())
+			s.Equal(`{"approved":false}`, string(req.GetDetails().GetPayloads()[0].GetData()))
+			return &workflowservice.RespondActivityTaskCanceledByIdResponse{}, nil
+		})
+	err := s.app.Run([]string{"", "--namespace", cliTestNamespace, "activity", "cancel", "--workflow-id", "wid", "--run-id", "rid", "--activity-id", "aid",
+		"--detail", `{"approved": false}`, "--identity", "operator"})
+	s.Nil(err)
+}
+
+func (s *cliAppSuite) TestHeartbeatActivity() {
+	s.frontendClient.EXPECT().RecordActivityTaskHeartbeatById(gomock.Any(), gomock.Any()).
+		Return(&workflowservice.RecordActivityTaskHeartbeatByIdResponse{CancelRequested: true}, nil)
+	err := s.app.Run([]string{"", "--namespace", cliTestNamespace, "activity", "heartbeat", "--workflow-id", "wid", "--run-id", "rid", "--activity-id", "aid",
+		"--detail", "waiting for approval", "--identity", "operator"})
+	s.Nil(err)
+}
+
+func (s *cliAppSuite) TestListActivities() {
+	s.sdkClient.On("ListWorkflow", mock.Anything, mock.MatchedBy(func(req *workflowservice.ListWorkflowExecutionsRequest) bool {
+		return req.GetQuery() == "(WorkflowType='payment') AND ExecutionStatus = 'Running'"
+	})).Return(listWorkflowExecutionsResponse, nil).Once()
+	s.frontendClient.EXPECT().DescribeWorkflowExecution(gomock.Any(), gomock.Any()).Return(&workflowservice.DescribeWorkflowExecutionResponse{
+		PendingActivities: []*workflowpb.PendingActivityInfo{
+			{
+				ActivityId:        "1",
+				ActivityType:      &commonpb.ActivityType{Name: "charge"},
+				State:             enumspb.PENDING_ACTIVITY_STATE_STARTED,
+				Attempt:           7,
+				LastHeartbeatTime: timestamp.TimePtr(time.Now().Add(-time.Hour)),
+			},
+			{
+				ActivityId:   "2",
+				ActivityType: &commonpb.ActivityType{Name: "notify"},
+				State:        enumspb.PENDING_ACTIVITY_STATE_SCHEDULED,
+				Attempt:      1,
+			},
+			// filtered out by --stale-heartbeat only
+			{
+				ActivityId:        "3",
+				ActivityType:      &commonpb.ActivityType{Name: "charge"},
+				State:             enumspb.PENDING_ACTIVITY_STATE_STARTED,
+				Attempt:           6,
+				LastHeartbeatTime: timestamp.TimePtr(time.Now().Add(-time.Minute)),
+			},
+			// filtered out by --min-attempt only
+			{
+				ActivityId:        "4",
+				ActivityType:      &commonpb.ActivityType{Name: "charge"},
+				State:             enumspb.PENDING_ACTIVITY_STATE_STARTED,
+				Attempt:           2,
+				LastHeartbeatTime: timestamp.TimePtr(time.Now().Add(-time.Hour)),
+			},
+		},
+	}, nil).Times(2)
+
+	var err error
+	out := s.captureOutput(func() {
+		err = s.app.Run([]string{"", "--namespace", cliTestNamespace, "activity", "list", "--query", "WorkflowType='payment'",
+			"--min-attempt", "5", "--stale-heartbeat", "10m", "-o", "json"})
+	})
+	s.Nil(err)
+	s.sdkClient.AssertExpectations(s.T())
+
+	var rows []pendingActivityRow
+	s.NoError(json.Unmarshal([]byte(out), &rows), out)
+	var activityIDs []string
+	for _, r := range rows {
+		activityIDs = append(activityIDs, r.ActivityId)
+	}
+	// one row for each of the two listed workflows
+	s.Equal([]string{"1", "1"}, activityIDs)
+}
+
+func (s *cliAppSuite) TestListActivities_DescribeFailed() {
+	s.sdkClient.On("ListWorkflow", mock.Anything, mock.Anything).Return(listWorkflowExecutionsResponse, nil).Once()
+	s.frontendClient.EXPECT().DescribeWorkflowExecution(gomock.Any(), gomock.Any()).Return(nil, serviceerror.NewNotFound("closed"))
+	s.frontendClient.EXPECT().DescribeWorkflowExecution(gomock.Any(), gomock.Any()).Return(nil, serviceerror.NewPermissionDenied("denied", ""))
+
+	errorCode := s.RunWithExitCode([]string{"", "--namespace", cliTestNamespace, "activity", "list"})
+	s.Equal(1, errorCode)
+}
+
 // TestParseTime tests the parsing of date argument in UTC and UnixNano formats
 func (s *cliAppSuite) TestParseTime() {
 	t, err := parseTime("", time.Date(1978, 8, 22, 0, 0, 0, 0, time.UTC), time.Now().UTC())
@@ -336,6 +662,31 @@ func workflowRun() sdkclient.WorkflowRun {
 	return workflowRunMock
 }
 
+// captureOutput returns what fn prints to stdout
+func (s *cliAppSuite) captureOutput(fn func()) string {
+	stdout := os.Stdout
+	defer func() { os.Stdout = stdout }()
+	r, w, err := os.Pipe()
+	s.NoError(err)
+	os.Stdout = w
+
+	out := make(chan string)
+	go func() {
+		b, _ := io.ReadAll(r)
+		out <- string(b)
+	}()
+	fn()
+	s.NoError(w.Close())
+	return <-out
+}
+
+// writeTempFile writes content to a file with the given name in a directory removed after the test
+func (s *cliAppSuite) writeTempFile(name, content string) string {
+	path := filepath.Join(s.T().TempDir(), name)
+	s.NoError(os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
 func (s *cliAppSuite) RunWithExitCode(arguments []string) int {
 	origExiter := cli.OsExiter
 	defer func() { cli.OsExiter = origExiter }()
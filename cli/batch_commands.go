@@ -35,7 +35,6 @@ import (
 	"go.temporal.io/api/batch/v1"
 	"go.temporal.io/api/workflowservice/v1"
 	"go.temporal.io/server/common/collection"
-	"go.temporal.io/server/common/payloads"
 )
 
 // DescribeBatchJob describe the status of the batch job
@@ -135,11 +134,22 @@ func BatchCancel(c *cli.Context) error {
 
 // BatchSignal send a signal to a list of workflows
 func BatchSignal(c *cli.Context) error {
-	signalName := c.String(FlagName)
+	signalName, err := requiredFlag(c, FlagName)
+	if err != nil {
+		return err
+	}
 	input := c.String(FlagInput)
 	operator := getCurrentUserFromEnv()
 
-	inputP, err := payloads.Encode(input)
+	var values []interface{}
+	if isRawPayloadInput(c) {
+		if values, err = rawPayloadInputs(c, [][]byte{[]byte(input)}); err != nil {
+			return err
+		}
+	} else {
+		values = []interface{}{input}
+	}
+	inputP, err := customDataConverter().ToPayloads(values...)
 	if err != nil {
 		return fmt.Errorf("unable to serialize signal input: %w", err)
 	}
@@ -25,6 +25,8 @@
 package cli
 
 import (
+	"context"
+
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/mock"
 	"go.temporal.io/api/workflowservice/v1"
@@ -63,6 +65,31 @@ func (s *cliAppSuite) TestStartBatchJob_Signal() {
 	s.sdkClient.AssertExpectations(s.T())
 }
 
+func (s *cliAppSuite) TestStartBatchJob_SignalPayloadInput() {
+	s.sdkClient.On("CountWorkflow", mock.Anything, mock.Anything).Return(&workflowservice.CountWorkflowExecutionsResponse{Count: 5}, nil).Once()
+	s.frontendClient.EXPECT().StartBatchOperation(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, req *workflowservice.StartBatchOperationRequest, _ ...interface{}) (*workflowservice.StartBatchOperationResponse, error) {
+			payload := req.GetSignalOperation().GetInput().GetPayloads()[0]
+			s.Equal("binary/plain", string(payload.GetMetadata()["encoding"]))
+			s.Equal("raw bytes", string(payload.GetData()))
+			return &workflowservice.StartBatchOperationResponse{}, nil
+		})
+	err := s.app.Run([]string{"", "workflow", "signal", "--name", "test-signal", "--query", "WorkflowType='test-type'", "--reason", "test-reason",
+		"--input", "raw bytes", "--input-encoding", "binary/plain", "--yes"})
+	s.Nil(err)
+	s.sdkClient.AssertExpectations(s.T())
+}
+
+func (s *cliAppSuite) TestStartBatchJob_SignalRequiresName() {
+	errorCode := s.RunWithExitCode([]string{"", "workflow", "signal", "--query", "WorkflowType='test-type'", "--reason", "test-reason", "--yes"})
+	s.Equal(1, errorCode)
+}
+
+func (s *cliAppSuite) TestStartBatchJob_SignalWithInputFile() {
+	errorCode := s.RunWithExitCode([]string{"", "workflow", "signal", "--name", "test-signal", "--query", "WorkflowType='test-type'", "--input-file-per-execution", "signals.jsonl", "--yes"})
+	s.Equal(1, errorCode)
+}
+
 func (s *cliAppSuite) TestStartBatchJob_Terminate() {
 	s.sdkClient.On("CountWorkflow", mock.Anything, mock.Anything).Return(&workflowservice.CountWorkflowExecutionsResponse{Count: 5}, nil).Once()
 	s.frontendClient.EXPECT().StartBatchOperation(gomock.Any(), gomock.Any()).Return(&workflowservice.StartBatchOperationResponse{}, nil).Times(1)
@@ -23,16 +23,63 @@
 package dataconverter
 
 import (
+	"fmt"
 	"net/http"
 	"strings"
 
+	commonpb "go.temporal.io/api/common/v1"
 	"go.temporal.io/sdk/converter"
 )
 
+const rawPayloadEncoding = "tctl/raw-payload"
+
 var (
-	dataConverter = converter.GetDefaultDataConverter()
+	dataConverter = newDefaultDataConverter()
+	// builtinDataConverter is the last data converter built by this package, which accepts RawPayload values
+	builtinDataConverter = dataConverter
 )
 
+// RawPayload is a value that is converted to exactly this payload. It still goes through the codec of a
+// remote data converter, so a fully formed payload can be encrypted like any other value.
+type RawPayload struct {
+	Payload *commonpb.Payload
+}
+
+// rawPayloadConverter converts RawPayload values and declines everything else
+type rawPayloadConverter struct{}
+
+func (rawPayloadConverter) ToPayload(value interface{}) (*commonpb.Payload, error) {
+	if raw, ok := value.(RawPayload); ok {
+		return raw.Payload, nil
+	}
+	return nil, nil
+}
+
+func (rawPayloadConverter) FromPayload(*commonpb.Payload, interface{}) error {
+	return fmt.Errorf("%s payloads can't be decoded", rawPayloadEncoding)
+}
+
+func (rawPayloadConverter) ToString(*commonpb.Payload) string {
+	return ""
+}
+
+func (rawPayloadConverter) Encoding() string {
+	return rawPayloadEncoding
+}
+
+// newDefaultDataConverter is the SDK default data converter that also accepts RawPayload values
+func newDefaultDataConverter() converter.DataConverter {
+	return converter.NewCompositeDataConverter(
+		rawPayloadConverter{},
+		converter.NewNilPayloadConverter(),
+		converter.NewByteSlicePayloadConverter(),
+		converter.NewProtoJSONPayloadConverter(),
+		converter.NewProtoPayloadConverter(),
+		converter.NewJSONPayloadConverter(),
+	)
+}
+
+// SetCurrent sets the data converter used by the CLI
 func SetCurrent(dc converter.DataConverter) {
 	dataConverter = dc
 }
@@ -41,7 +88,7 @@ func SetRemoteEndpoint(endpoint string, namespace string, auth string) {
 	endpoint = strings.ReplaceAll(endpoint, "{namespace}", namespace)
 
 	dataConverter = converter.NewRemoteDataConverter(
-		converter.GetDefaultDataConverter(),
+		newDefaultDataConverter(),
 		converter.RemoteDataConverterOptions{
 			Endpoint: endpoint,
 			ModifyRequest: func(req *http.Request) error {
@@ -54,8 +101,15 @@ func SetRemoteEndpoint(endpoint string, namespace string, auth string) {
 			},
 		},
 	)
+	builtinDataConverter = dataConverter
 }
 
 func GetCurrent() converter.DataConverter {
 	return dataConverter
 }
+
+// AcceptsRawPayloads reports whether the current data converter accepts RawPayload values. Converters set
+// with SetCurrent, such as data converter plugins, don't.
+func AcceptsRawPayloads() bool {
+	return dataConverter == builtinDataConverter
+}
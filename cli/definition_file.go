@@ -0,0 +1,167 @@
+// The MIT License
+//
+// Copyright (c) 2022 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// Definition files describe server resources such as schedules and namespaces for apply and export commands
+const (
+	definitionFormatYAML = "yaml"
+	definitionFormatJSON = "json"
+)
+
+// readDefinitionFile decodes a YAML or JSON definition file, rejecting unknown fields
+func readDefinitionFile(path string, kind string, v interface{}) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("unable to read %s file: %w", kind, err)
+	}
+	// JSON is valid YAML, so both formats are read the same way
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(v); err != nil {
+		return fmt.Errorf("unable to parse %s file: %w", kind, err)
+	}
+	return nil
+}
+
+func marshalDefinition(v interface{}, kind string, format string) ([]byte, error) {
+	switch format {
+	case definitionFormatJSON:
+		data, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("unable to serialize %s: %w", kind, err)
+		}
+		return append(data, '\n'), nil
+	case definitionFormatYAML, "":
+		var buf bytes.Buffer
+		encoder := yaml.NewEncoder(&buf)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(v); err != nil {
+			return nil, fmt.Errorf("unable to serialize %s: %w", kind, err)
+		}
+		return buf.Bytes(), nil
+	default:
+		return nil, fmt.Errorf("unknown format %q, expected %s or %s", format, definitionFormatYAML, definitionFormatJSON)
+	}
+}
+
+// writeDefinition writes a definition in the --format format to --output-filename, or to stdout if not set
+func writeDefinition(c *cli.Context, kind string, v interface{}) error {
+	data, err := marshalDefinition(v, kind, c.String(FlagFormat))
+	if err != nil {
+		return err
+	}
+	if c.IsSet(FlagOutputFilename) {
+		if err := os.WriteFile(c.String(FlagOutputFilename), data, 0644); err != nil {
+			return fmt.Errorf("unable to write %s file: %w", kind, err)
+		}
+		return nil
+	}
+	_, err = os.Stdout.Write(data)
+	return err
+}
+
+// diffFields returns one line per changed field between two definitions, by their JSON form,
+// e.g. `spec.timeZone: "UTC" -> "Europe/Berlin"`
+func diffFields(before interface{}, after interface{}) ([]string, error) {
+	beforeFields, err := flattenJSONFields(before)
+	if err != nil {
+		return nil, err
+	}
+	afterFields, err := flattenJSONFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make(map[string]struct{})
+	for path := range beforeFields {
+		paths[path] = struct{}{}
+	}
+	for path := range afterFields {
+		paths[path] = struct{}{}
+	}
+	var sorted []string
+	for path := range paths {
+		sorted = append(sorted, path)
+	}
+	sort.Strings(sorted)
+
+	var changes []string
+	for _, path := range sorted {
+		b, inBefore := beforeFields[path]
+		a, inAfter := afterFields[path]
+		if !inBefore {
+			b = "(unset)"
+		}
+		if !inAfter {
+			a = "(unset)"
+		}
+		if b != a {
+			changes = append(changes, fmt.Sprintf("%s: %s -> %s", path, b, a))
+		}
+	}
+	return changes, nil
+}
+
+func flattenJSONFields(v interface{}) (map[string]string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode definition: %w", err)
+	}
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, fmt.Errorf("unable to decode definition: %w", err)
+	}
+	fields := make(map[string]string)
+	flattenJSONValue("", value, fields)
+	return fields, nil
+}
+
+func flattenJSONValue(path string, value interface{}, fields map[string]string) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			flattenJSONValue(strings.TrimPrefix(path+"."+key, "."), child, fields)
+		}
+	case []interface{}:
+		for i, child := range v {
+			flattenJSONValue(fmt.Sprintf("%s[%d]", path, i), child, fields)
+		}
+	default:
+		data, _ := json.Marshal(v)
+		fields[path] = string(data)
+	}
+}
@@ -53,6 +53,7 @@ import (
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/metadata"
 
+	"github.com/temporalio/tctl/cli/dataconverter"
 	"github.com/temporalio/tctl/cli/headersprovider"
 	"github.com/temporalio/tctl/cli/plugin"
 )
@@ -122,6 +123,7 @@ func (b *clientFactory) SDKClient(c *cli.Context, namespace string) sdkclient.Cl
 			TLS: tlsConfig,
 		},
 		HeadersProvider: headersprovider.GetCurrent(),
+		DataConverter:   dataconverter.GetCurrent(),
 	})
 	if err != nil {
 		b.logger.Fatal("Failed to create SDK client", tag.Error(err))
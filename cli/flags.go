@@ -29,6 +29,7 @@ import (
 
 	"github.com/temporalio/tctl-kit/pkg/output"
 	"github.com/urfave/cli/v2"
+	"golang.org/x/exp/slices"
 )
 
 // Flags used to specify cli command line arguments
@@ -54,6 +55,7 @@ var (
 	FlagInput                      = "input"
 	FlagInputAlias                 = []string{"i"}
 	FlagInputFile                  = "input-file"
+	FlagInputFilePerExecution      = "input-file-per-execution"
 	FlagExcludeFile                = "exclude-file"
 	FlagInputSeparator             = "input-separator"
 	FlagParallelism                = "input-parallelism"
@@ -133,6 +135,44 @@ var (
 	FlagOutputAlias                = []string{"o"}
 	FlagClusterAddress             = "frontend-address"
 	FlagClusterEnableConnection    = "enable-connection"
+	FlagCount                      = "count"
+	FlagWait                       = "wait"
+	FlagDir                        = "dir"
+	FlagBundle                     = "bundle"
+	FlagPreview                    = "preview"
+	FlagRetryable                  = "retryable"
+	FlagFailureType                = "failure-type"
+	FlagInputEncoding              = "input-encoding"
+	FlagInputMeta                  = "input-meta"
+	FlagInputPayload               = "input-payload"
+	FlagMinAttempt                 = "min-attempt"
+	FlagStaleHeartbeat             = "stale-heartbeat"
+	FlagAllTypes                   = "all-types"
+	FlagIncludeStatus              = "include-status"
+	FlagWatch                      = "watch"
+	FlagBuildID                    = "build-id"
+	FlagExistingCompatibleBuildID  = "existing-compatible-build-id"
+	FlagSetAsDefault               = "set-as-default"
+	FlagMaxDepth                   = "max-depth"
+	FlagMinPollers                 = "min-pollers"
+	FlagMaxLastAccess              = "max-last-access"
+	FlagMaxBacklog                 = "max-backlog"
+	FlagWithPollers                = "with-pollers"
+	FlagFile                       = "file"
+	FlagFileAlias                  = []string{"f"}
+	FlagFormat                     = "format"
+	FlagPatch                      = "patch"
+	FlagRemoveCalendar             = "remove-calendar"
+	FlagPaused                     = "paused"
+	FlagScheduleIDPrefix           = "schedule-id-prefix"
+	FlagAll                        = "all"
+	FlagChunk                      = "chunk"
+	FlagTerminateOld               = "terminate-old"
+	FlagTo                         = "to"
+	FlagBatch                      = "batch"
+	FlagWaitTimeout                = "wait-timeout"
+	FlagNamespaceRules             = "namespace-rules"
+	FlagPrune                      = "prune"
 )
 
 var flagsForExecution = []cli.Flag{
@@ -169,15 +209,20 @@ var flagsForShowWorkflow = []cli.Flag{
 		Usage:   "Follow the progress of Workflow Execution",
 		Value:   false,
 	},
+	&cli.BoolFlag{
+		Name:  FlagArchive,
+		Usage: "Read the history from the archival store if the Workflow Execution is no longer retained, requires --run-id (EXPERIMENTAL)",
+	},
 }
 
-var flagsForStartWorkflow = append(flagsForStartWorkflowT,
+var flagsForStartWorkflow = slices.Clip(append(append(flagsForStartWorkflowT, flagsForPayloadInput...),
 	&cli.StringFlag{
 		Name:     FlagType,
 		Usage:    "Workflow type name",
 		Required: true,
-	})
+	}))
 
+// Schedules store the input of the workflow action, so they take no payload input flags.
 var flagsForStartWorkflowLong = append(flagsForStartWorkflowT,
 	&cli.StringFlag{
 		Name:     FlagWorkflowType,
@@ -277,7 +322,8 @@ func getFlagsForCount() []cli.Flag {
 	}
 }
 
-var flagsForStackTraceQuery = append(flagsForExecution, []cli.Flag{
+// Clipped so that commands appending their own flags never share a backing array.
+var flagsForStackTraceQuery = slices.Clip(append(flagsForExecution, []cli.Flag{
 	&cli.StringFlag{
 		Name:    FlagInput,
 		Aliases: FlagInputAlias,
@@ -292,7 +338,7 @@ var flagsForStackTraceQuery = append(flagsForExecution, []cli.Flag{
 		Name:  FlagQueryRejectCondition,
 		Usage: "Optional flag to reject queries based on Workflow state. Valid values are \"not_open\" and \"not_completed_cleanly\"",
 	},
-}...)
+}...))
 
 var flagsForTraceWorkflow = []cli.Flag{
 	&cli.IntFlag{
@@ -29,6 +29,7 @@ import (
 )
 
 // by default we don't require any namespace data. But this can be overridden by calling SetRequiredNamespaceDataKeys()
+// or with data rules in the namespace rules file
 var requiredNamespaceDataKeys []string
 
 // SetRequiredNamespaceDataKeys will set requiredNamespaceDataKeys
@@ -74,6 +75,45 @@ func newNamespaceCommands() []*cli.Command {
 				return UpdateNamespace(c)
 			},
 		},
+		{
+			Name:        "lint",
+			Usage:       "Check Namespaces against the Namespace rules",
+			Description: "Lists every rule that existing Namespaces don't follow. The same rules are checked by register, update and apply before changing a Namespace",
+			Flags:       lintNamespacesFlags,
+			ArgsUsage:   "[namespace_name...]",
+			Action: func(c *cli.Context) error {
+				return LintNamespaces(c)
+			},
+		},
+		{
+			Name:        "failover",
+			Usage:       "Change the active cluster of global Namespaces",
+			Description: "Checks that the Namespace is global, that the target cluster is one of its clusters and that the connection to it is enabled, then asks to type the Namespace name to confirm. After the update, waits until the Namespace shows a new failover version. With --batch, every Namespace matching the pattern is failed over, and nothing is changed unless all of them pass the checks. Each Namespace is confirmed by typing its name",
+			Flags:       failoverNamespaceFlags,
+			ArgsUsage:   "namespace_name",
+			Action: func(c *cli.Context) error {
+				return FailoverNamespace(c)
+			},
+		},
+		{
+			Name:        "apply",
+			Usage:       "Register or update Namespaces from a YAML or JSON definition",
+			Description: "Namespaces that don't exist are registered. For the others, the differences with the file are shown and only those are updated after confirmation. Fields that are not in the file are left unchanged. Use `tctl namespace export` to get the definition of existing Namespaces",
+			Flags:       applyNamespacesFlags,
+			ArgsUsage:   " ",
+			Action: func(c *cli.Context) error {
+				return ApplyNamespaces(c)
+			},
+		},
+		{
+			Name:      "export",
+			Usage:     "Write the definition of Namespaces in the format read by apply",
+			Flags:     exportNamespacesFlags,
+			ArgsUsage: "[namespace_name...]",
+			Action: func(c *cli.Context) error {
+				return ExportNamespaces(c)
+			},
+		},
 		{
 			Name:      "delete",
 			Usage:     "Delete existing Namespace",
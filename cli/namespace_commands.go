@@ -28,6 +28,7 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/temporalio/tctl-kit/pkg/color"
 	"github.com/temporalio/tctl-kit/pkg/output"
@@ -48,6 +49,11 @@ func RegisterNamespace(c *cli.Context) error {
 		return err
 	}
 
+	rules, err := loadNamespaceRules(c)
+	if err != nil {
+		return err
+	}
+
 	description := c.String(FlagDescription)
 	ownerEmail := c.String(FlagOwnerEmail)
 
@@ -77,12 +83,6 @@ func RegisterNamespace(c *cli.Context) error {
 			return err
 		}
 	}
-	if len(requiredNamespaceDataKeys) > 0 {
-		err = validateNamespaceDataRequiredKeys(data)
-		if err != nil {
-			return err
-		}
-	}
 
 	var activeCluster string
 	if c.IsSet(FlagActiveCluster) {
@@ -122,6 +122,9 @@ func RegisterNamespace(c *cli.Context) error {
 		VisibilityArchivalUri:            c.String(FlagVisibilityArchivalURI),
 		IsGlobalNamespace:                isGlobalNamespace,
 	}
+	if err := rules.check(namespaceFileEntryFromRegister(request)); err != nil {
+		return err
+	}
 
 	ctx, cancel := newContext(c)
 	defer cancel()
@@ -246,6 +249,30 @@ func UpdateNamespace(c *cli.Context) error {
 			Config:            updateConfig,
 			ReplicationConfig: replicationConfig,
 		}
+
+		rules, err := loadNamespaceRules(c)
+		if err != nil {
+			return err
+		}
+		changes := &namespaceFileEntry{
+			Description:        description,
+			OwnerEmail:         ownerEmail,
+			Retention:          formatRetention(retention),
+			Data:               data,
+			HistoryArchival:    namespaceFileArchivalFromConfig(archState, c.String(FlagHistoryArchivalURI)),
+			VisibilityArchival: namespaceFileArchivalFromConfig(archVisState, c.String(FlagVisibilityArchivalURI)),
+		}
+		for _, cluster := range clusters {
+			changes.Clusters = append(changes.Clusters, cluster.GetClusterName())
+		}
+		current := namespaceFileEntryFromDescribe(resp)
+		warnings, err := rules.checkUpdate(current, current.managedBy(changes))
+		if err != nil {
+			return err
+		}
+		for _, warning := range warnings {
+			fmt.Println(color.Yellow(c, "Namespace %s: %s", ns, warning))
+		}
 	}
 
 	_, err = client.UpdateNamespace(ctx, updateRequest)
@@ -308,6 +335,21 @@ func ListNamespaces(c *cli.Context) error {
 	return nil
 }
 
+// deleteNamespacePollInterval is how often the deleted namespace is checked with --wait
+var deleteNamespacePollInterval = 5 * time.Second
+
+// namespaceDeleteImpact is what is lost when a namespace is deleted
+type namespaceDeleteImpact struct {
+	Namespace          string
+	State              string
+	Retention          string
+	HistoryArchival    string
+	VisibilityArchival string
+	OpenWorkflows      string
+	TotalWorkflows     string
+	Schedules          string
+}
+
 // DeleteNamespace deletes namespace.
 func DeleteNamespace(c *cli.Context) error {
 	ns, err := getNamespaceFromArgs(c)
@@ -315,15 +357,28 @@ func DeleteNamespace(c *cli.Context) error {
 		return err
 	}
 
+	impact, err := getNamespaceDeleteImpact(c, ns)
+	if err != nil {
+		return err
+	}
+	po := &output.PrintOptions{
+		Fields:       []string{"Namespace", "State", "Retention", "HistoryArchival", "VisibilityArchival", "OpenWorkflows", "TotalWorkflows", "Schedules"},
+		ForceFields:  true,
+		OutputFormat: output.Card,
+	}
+	if err := output.PrintItems(c, []interface{}{impact}, po); err != nil {
+		return err
+	}
+
 	promptMsg := color.Red(c, "Are you sure you want to delete namespace %s? Type namespace name to confirm:", ns)
-	if !prompt(promptMsg, c.Bool(FlagYes), ns) {
+	if !promptName(promptMsg, c.Bool(FlagYes), ns) {
 		return nil
 	}
 
 	client := cFactory.OperatorClient(c)
 	ctx, cancel := newContext(c)
 	defer cancel()
-	_, err = client.DeleteNamespace(ctx, &operatorservice.DeleteNamespaceRequest{
+	resp, err := client.DeleteNamespace(ctx, &operatorservice.DeleteNamespaceRequest{
 		Namespace: ns,
 	})
 	if err != nil {
@@ -337,9 +392,107 @@ func DeleteNamespace(c *cli.Context) error {
 	}
 
 	fmt.Println(color.Green(c, "Namespace %s has been deleted", ns))
+	if resp.GetDeletedNamespace() != "" {
+		fmt.Printf("Its resources are being removed under the name %s\n", resp.GetDeletedNamespace())
+	}
+	if c.Bool(FlagWait) && resp.GetDeletedNamespace() != "" {
+		return waitForNamespaceDeletion(c, resp.GetDeletedNamespace())
+	}
 	return nil
 }
 
+func getNamespaceDeleteImpact(c *cli.Context, ns string) (*namespaceDeleteImpact, error) {
+	client := cFactory.FrontendClient(c)
+	ctx, cancel := newContext(c)
+	defer cancel()
+	resp, err := client.DescribeNamespace(ctx, &workflowservice.DescribeNamespaceRequest{Namespace: ns})
+	if err != nil {
+		switch err.(type) {
+		case *serviceerror.NamespaceNotFound:
+			return nil, err
+		default:
+			return nil, fmt.Errorf("unable to describe namespace: %w", err)
+		}
+	}
+
+	config := resp.GetConfig()
+	impact := &namespaceDeleteImpact{
+		Namespace:          ns,
+		State:              resp.GetNamespaceInfo().GetState().String(),
+		Retention:          formatRetention(timestamp.DurationValue(config.GetWorkflowExecutionRetentionTtl())),
+		HistoryArchival:    formatArchival(config.GetHistoryArchivalState(), config.GetHistoryArchivalUri()),
+		VisibilityArchival: formatArchival(config.GetVisibilityArchivalState(), config.GetVisibilityArchivalUri()),
+		OpenWorkflows:      countNamespaceWorkflows(c, client, ns, "ExecutionStatus = 'Running'"),
+		TotalWorkflows:     countNamespaceWorkflows(c, client, ns, ""),
+		Schedules:          countNamespaceSchedules(c, client, ns),
+	}
+	return impact, nil
+}
+
+// countNamespaceWorkflows returns the number of workflows as text, as it can't be counted with every visibility store
+func countNamespaceWorkflows(c *cli.Context, client workflowservice.WorkflowServiceClient, ns, query string) string {
+	ctx, cancel := newContext(c)
+	defer cancel()
+	resp, err := client.CountWorkflowExecutions(ctx, &workflowservice.CountWorkflowExecutionsRequest{
+		Namespace: ns,
+		Query:     query,
+	})
+	if err != nil {
+		return fmt.Sprintf("unknown (%v)", err)
+	}
+	return strconv.FormatInt(resp.GetCount(), 10)
+}
+
+func countNamespaceSchedules(c *cli.Context, client workflowservice.WorkflowServiceClient, ns string) string {
+	count := 0
+	var token []byte
+	for more := true; more; more = len(token) > 0 {
+		ctx, cancel := newContext(c)
+		resp, err := client.ListSchedules(ctx, &workflowservice.ListSchedulesRequest{
+			Namespace:     ns,
+			NextPageToken: token,
+		})
+		cancel()
+		if err != nil {
+			return fmt.Sprintf("unknown (%v)", err)
+		}
+		count += len(resp.GetSchedules())
+		token = resp.GetNextPageToken()
+	}
+	return strconv.Itoa(count)
+}
+
+func formatArchival(state enumspb.ArchivalState, uri string) string {
+	if state != enumspb.ARCHIVAL_STATE_ENABLED {
+		return "disabled"
+	}
+	return fmt.Sprintf("enabled (%s)", uri)
+}
+
+// waitForNamespaceDeletion polls the renamed namespace until the server has removed it with all its workflows
+func waitForNamespaceDeletion(c *cli.Context, deletedNamespace string) error {
+	client := cFactory.FrontendClient(c)
+	timeout := c.Duration(FlagWaitTimeout)
+	deadline := time.Now().Add(timeout)
+	for {
+		ctx, cancel := newContext(c)
+		_, err := client.DescribeNamespace(ctx, &workflowservice.DescribeNamespaceRequest{Namespace: deletedNamespace})
+		cancel()
+		if _, ok := err.(*serviceerror.NamespaceNotFound); ok {
+			fmt.Println(color.Green(c, "Namespace %s has been removed", deletedNamespace))
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("unable to describe namespace %s: %w", deletedNamespace, err)
+		}
+		left := countNamespaceWorkflows(c, client, deletedNamespace, "")
+		if time.Now().After(deadline) {
+			return fmt.Errorf("namespace %s was not removed within %v, workflows left: %s. The server keeps removing it in the background", deletedNamespace, timeout, left)
+		}
+		fmt.Printf("Waiting for namespace %s to be removed, workflows left: %s\n", deletedNamespace, left)
+		time.Sleep(deleteNamespacePollInterval)
+	}
+}
+
 func printNamespace(c *cli.Context, resp *workflowservice.DescribeNamespaceResponse) error {
 	po := &output.PrintOptions{
 		Fields:       []string{"NamespaceInfo.Name", "NamespaceInfo.Id", "NamespaceInfo.Description", "NamespaceInfo.OwnerEmail", "NamespaceInfo.State", "Config.WorkflowExecutionRetentionTtl", "ReplicationConfig.ActiveClusterName", "ReplicationConfig.Clusters", "Config.HistoryArchivalState", "Config.VisibilityArchivalState", "IsGlobalNamespace", "FailoverVersion", "FailoverHistory"},
@@ -415,13 +568,3 @@ func getNamespaceFromIDArgs(c *cli.Context) (string, string, error) {
 
 	return ns, nsID, nil
 }
-
-func validateNamespaceDataRequiredKeys(namespaceData map[string]string) error {
-	for _, k := range requiredNamespaceDataKeys {
-		_, ok := namespaceData[k]
-		if !ok {
-			return fmt.Errorf("missing namespace data key %v. Required keys: %v", k, requiredNamespaceDataKeys)
-		}
-	}
-	return nil
-}
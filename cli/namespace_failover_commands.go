@@ -0,0 +1,229 @@
+// The MIT License
+//
+// Copyright (c) 2022 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cli
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/temporalio/tctl-kit/pkg/color"
+	"github.com/temporalio/tctl-kit/pkg/output"
+	"github.com/urfave/cli/v2"
+	"go.temporal.io/api/operatorservice/v1"
+	replicationpb "go.temporal.io/api/replication/v1"
+	"go.temporal.io/api/workflowservice/v1"
+)
+
+// failoverPollInterval is how often DescribeNamespace is called while waiting for a failover
+var failoverPollInterval = time.Second
+
+type namespaceFailover struct {
+	Namespace        string
+	ActiveCluster    string
+	TargetCluster    string
+	FailoverVersion  int64
+	ReplicationState string
+}
+
+// FailoverNamespace changes the active cluster of global namespaces after checking that the target cluster can take them
+func FailoverNamespace(c *cli.Context) error {
+	target := c.String(FlagTo)
+	client := cFactory.FrontendClient(c)
+
+	namespaces, err := failoverNamespaces(c, client)
+	if err != nil {
+		return err
+	}
+	if err := checkFailoverCluster(c, target); err != nil {
+		return err
+	}
+
+	var failovers []*namespaceFailover
+	for _, ns := range namespaces {
+		name := ns.GetNamespaceInfo().GetName()
+		if !ns.GetIsGlobalNamespace() {
+			return fmt.Errorf("namespace %s is not a global namespace", name)
+		}
+		var clusters []string
+		inClusters := false
+		for _, cluster := range ns.GetReplicationConfig().GetClusters() {
+			clusters = append(clusters, cluster.GetClusterName())
+			inClusters = inClusters || cluster.GetClusterName() == target
+		}
+		if !inClusters {
+			return fmt.Errorf("cluster %s is not in the clusters of namespace %s: %s", target, name, strings.Join(clusters, ", "))
+		}
+		if ns.GetReplicationConfig().GetActiveClusterName() == target {
+			fmt.Printf("Namespace %s is already active in cluster %s\n", name, target)
+			continue
+		}
+		failovers = append(failovers, &namespaceFailover{
+			Namespace:        name,
+			ActiveCluster:    ns.GetReplicationConfig().GetActiveClusterName(),
+			TargetCluster:    target,
+			FailoverVersion:  ns.GetFailoverVersion(),
+			ReplicationState: ns.GetReplicationConfig().GetState().String(),
+		})
+	}
+	if len(failovers) == 0 {
+		return nil
+	}
+
+	var items []interface{}
+	for _, f := range failovers {
+		items = append(items, f)
+	}
+	po := &output.PrintOptions{
+		Fields:      []string{"Namespace", "ActiveCluster", "TargetCluster", "FailoverVersion", "ReplicationState"},
+		ForceFields: true,
+	}
+	if err := output.PrintItems(c, items, po); err != nil {
+		return err
+	}
+
+	// every namespace is confirmed by typing its name, also in batch mode
+	failed, skipped := 0, 0
+	for _, f := range failovers {
+		promptMsg := color.Red(c, "Are you sure you want to fail over namespace %s from cluster %s to cluster %s? Type namespace name to confirm:", f.Namespace, f.ActiveCluster, target)
+		if !promptName(promptMsg, c.Bool(FlagYes), f.Namespace) {
+			skipped++
+			fmt.Println(color.Yellow(c, "Namespace %s: skipped", f.Namespace))
+			continue
+		}
+		version, err := failoverNamespace(c, client, f)
+		if err != nil {
+			failed++
+			fmt.Println(color.Red(c, "Namespace %s: %v", f.Namespace, err))
+			continue
+		}
+		fmt.Println(color.Green(c, "Namespace %s is active in cluster %s with failover version %d", f.Namespace, target, version))
+	}
+	if skipped > 0 {
+		fmt.Println(color.Yellow(c, "Skipped %d of %d namespaces", skipped, len(failovers)))
+	}
+	if failed > 0 {
+		return fmt.Errorf("unable to fail over %d of %d namespaces", failed, len(failovers))
+	}
+	return nil
+}
+
+// failoverNamespaces returns the namespace given as argument, or the namespaces matching the batch pattern
+func failoverNamespaces(c *cli.Context, client workflowservice.WorkflowServiceClient) ([]*workflowservice.DescribeNamespaceResponse, error) {
+	if !c.IsSet(FlagBatch) {
+		ns, err := getNamespaceFromArgs(c)
+		if err != nil {
+			return nil, err
+		}
+		ctx, cancel := newContext(c)
+		defer cancel()
+		resp, err := client.DescribeNamespace(ctx, &workflowservice.DescribeNamespaceRequest{Namespace: ns})
+		if err != nil {
+			return nil, fmt.Errorf("unable to describe namespace %s: %w", ns, err)
+		}
+		return []*workflowservice.DescribeNamespaceResponse{resp}, nil
+	}
+
+	if c.Args().Len() > 0 {
+		return nil, fmt.Errorf("namespace name can't be given with --%s", FlagBatch)
+	}
+	pattern := c.String(FlagBatch)
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, fmt.Errorf("option %s format is invalid: %w", FlagBatch, err)
+	}
+	all, err := getAllNamespaces(c, client)
+	if err != nil {
+		return nil, err
+	}
+	var namespaces []*workflowservice.DescribeNamespaceResponse
+	for _, ns := range all {
+		if matched, _ := path.Match(pattern, ns.GetNamespaceInfo().GetName()); matched {
+			namespaces = append(namespaces, ns)
+		}
+	}
+	if len(namespaces) == 0 {
+		return nil, fmt.Errorf("no namespace matches %s", pattern)
+	}
+	return namespaces, nil
+}
+
+// checkFailoverCluster verifies that the target cluster is known and connected
+func checkFailoverCluster(c *cli.Context, target string) error {
+	client := cFactory.OperatorClient(c)
+	var token []byte
+	for more := true; more; more = len(token) > 0 {
+		ctx, cancel := newContext(c)
+		resp, err := client.ListClusters(ctx, &operatorservice.ListClustersRequest{NextPageToken: token})
+		cancel()
+		if err != nil {
+			return fmt.Errorf("unable to list clusters: %w", err)
+		}
+		for _, cluster := range resp.GetClusters() {
+			if cluster.GetClusterName() != target {
+				continue
+			}
+			if !cluster.GetIsConnectionEnabled() {
+				return fmt.Errorf("connection to cluster %s at %s is not enabled", target, cluster.GetAddress())
+			}
+			fmt.Printf("Cluster %s at %s is connected\n", target, cluster.GetAddress())
+			return nil
+		}
+		token = resp.GetNextPageToken()
+	}
+	return fmt.Errorf("cluster %s is unknown", target)
+}
+
+// failoverNamespace sets the active cluster of a namespace and waits until DescribeNamespace shows the new failover version
+func failoverNamespace(c *cli.Context, client workflowservice.WorkflowServiceClient, f *namespaceFailover) (int64, error) {
+	ctx, cancel := newContext(c)
+	_, err := client.UpdateNamespace(ctx, &workflowservice.UpdateNamespaceRequest{
+		Namespace: f.Namespace,
+		ReplicationConfig: &replicationpb.NamespaceReplicationConfig{
+			ActiveClusterName: f.TargetCluster,
+		},
+	})
+	cancel()
+	if err != nil {
+		return 0, fmt.Errorf("unable to update namespace: %w", err)
+	}
+
+	deadline := time.Now().Add(c.Duration(FlagWaitTimeout))
+	for {
+		ctx, cancel := newContext(c)
+		resp, err := client.DescribeNamespace(ctx, &workflowservice.DescribeNamespaceRequest{Namespace: f.Namespace})
+		cancel()
+		if err != nil {
+			return 0, fmt.Errorf("unable to describe namespace: %w", err)
+		}
+		if resp.GetReplicationConfig().GetActiveClusterName() == f.TargetCluster && resp.GetFailoverVersion() != f.FailoverVersion {
+			return resp.GetFailoverVersion(), nil
+		}
+		if time.Now().After(deadline) {
+			return 0, fmt.Errorf("failover version is still %d after %v", resp.GetFailoverVersion(), c.Duration(FlagWaitTimeout))
+		}
+		time.Sleep(failoverPollInterval)
+	}
+}
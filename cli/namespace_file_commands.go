@@ -0,0 +1,486 @@
+// The MIT License
+//
+// Copyright (c) 2022 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/temporalio/tctl-kit/pkg/color"
+	"github.com/urfave/cli/v2"
+	enumspb "go.temporal.io/api/enums/v1"
+	namespacepb "go.temporal.io/api/namespace/v1"
+	replicationpb "go.temporal.io/api/replication/v1"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/server/common/primitives/timestamp"
+)
+
+// namespaceFile is the definition of namespaces as written in YAML or JSON files
+type namespaceFile struct {
+	Namespaces []*namespaceFileEntry `yaml:"namespaces" json:"namespaces"`
+}
+
+// namespaceFileEntry is the configuration of one namespace. Fields left empty are not managed by the file:
+// apply keeps their current value, or the server default when registering. Data only sets the listed keys.
+type namespaceFileEntry struct {
+	Name               string                 `yaml:"name" json:"name"`
+	Description        string                 `yaml:"description,omitempty" json:"description,omitempty"`
+	OwnerEmail         string                 `yaml:"ownerEmail,omitempty" json:"ownerEmail,omitempty"`
+	Retention          string                 `yaml:"retention,omitempty" json:"retention,omitempty"`
+	Global             *bool                  `yaml:"global,omitempty" json:"global,omitempty"`
+	ActiveCluster      string                 `yaml:"activeCluster,omitempty" json:"activeCluster,omitempty"`
+	Clusters           []string               `yaml:"clusters,omitempty" json:"clusters,omitempty"`
+	Data               map[string]string      `yaml:"data,omitempty" json:"data,omitempty"`
+	HistoryArchival    *namespaceFileArchival `yaml:"historyArchival,omitempty" json:"historyArchival,omitempty"`
+	VisibilityArchival *namespaceFileArchival `yaml:"visibilityArchival,omitempty" json:"visibilityArchival,omitempty"`
+}
+
+type namespaceFileArchival struct {
+	// State is enabled or disabled, like the archival state flags
+	State string `yaml:"state" json:"state"`
+	URI   string `yaml:"uri,omitempty" json:"uri,omitempty"`
+}
+
+// namespacePlan is what apply does to one namespace
+type namespacePlan struct {
+	desired  *namespaceFileEntry
+	current  *namespaceFileEntry // nil if the namespace has to be registered
+	changes  []string
+	warnings []string
+}
+
+// ApplyNamespaces registers the namespaces of a file that don't exist and updates the others where they differ from the file
+func ApplyNamespaces(c *cli.Context) error {
+	file, err := readNamespaceFile(c.String(FlagFile))
+	if err != nil {
+		return err
+	}
+	rules, err := loadNamespaceRules(c)
+	if err != nil {
+		return err
+	}
+	client := cFactory.FrontendClient(c)
+
+	var plans []*namespacePlan
+	for _, desired := range file.Namespaces {
+		plan, err := planNamespace(c, client, rules, desired)
+		if err != nil {
+			return err
+		}
+		plans = append(plans, plan)
+	}
+
+	pending := 0
+	for _, plan := range plans {
+		for _, warning := range plan.warnings {
+			fmt.Println(color.Yellow(c, "Namespace %s: %s", plan.desired.Name, warning))
+		}
+		switch {
+		case plan.current == nil:
+			pending++
+			fmt.Printf("Namespace %s will be registered\n", plan.desired.Name)
+		case len(plan.changes) > 0:
+			pending++
+			fmt.Printf("Namespace %s will be updated:\n", plan.desired.Name)
+			for _, change := range plan.changes {
+				fmt.Printf("  %s\n", change)
+			}
+		default:
+			fmt.Printf("Namespace %s is up to date\n", plan.desired.Name)
+		}
+	}
+	if pending == 0 {
+		fmt.Println(color.Green(c, "All namespaces are up to date"))
+		return nil
+	}
+	if c.Bool(FlagDryRun) {
+		return nil
+	}
+	if !promptYes(fmt.Sprintf("Apply changes to %v namespaces? Y/N", color.Yellow(c, "%v", pending)), c.Bool(FlagYes)) {
+		return nil
+	}
+
+	for _, plan := range plans {
+		if plan.current == nil {
+			err = registerNamespaceFromFile(c, client, plan.desired)
+		} else if len(plan.changes) > 0 {
+			err = updateNamespaceFromFile(c, client, plan)
+		} else {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+	fmt.Println(color.Green(c, "Applied changes to %d namespaces", pending))
+	return nil
+}
+
+func planNamespace(c *cli.Context, client workflowservice.WorkflowServiceClient, rules *namespaceRules, desired *namespaceFileEntry) (*namespacePlan, error) {
+	plan := &namespacePlan{desired: desired}
+	ctx, cancel := newContext(c)
+	defer cancel()
+	resp, err := client.DescribeNamespace(ctx, &workflowservice.DescribeNamespaceRequest{Namespace: desired.Name})
+	if _, ok := err.(*serviceerror.NamespaceNotFound); ok {
+		registered := *desired
+		if registered.Retention == "" {
+			registered.Retention = formatRetention(defaultNamespaceRetention)
+		}
+		if err := rules.check(&registered); err != nil {
+			return nil, err
+		}
+		return plan, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("unable to describe namespace %s: %w", desired.Name, err)
+	}
+
+	plan.current = namespaceFileEntryFromDescribe(resp)
+	managed := plan.current.managedBy(desired)
+	if plan.current.Global != nil && *plan.current.Global && !*managed.Global {
+		return nil, fmt.Errorf("namespace %s is global and can't be made local", desired.Name)
+	}
+	for key := range plan.current.Data {
+		if _, ok := desired.Data[key]; !ok && desired.Data != nil {
+			plan.warnings = append(plan.warnings, fmt.Sprintf("data key %s is not in the file, but data keys can't be removed", key))
+		}
+	}
+	if plan.changes, err = diffFields(plan.current, managed); err != nil {
+		return nil, err
+	}
+	// namespaces that are not changed are only checked by lint
+	if len(plan.changes) > 0 {
+		warnings, err := rules.checkUpdate(plan.current, managed)
+		if err != nil {
+			return nil, err
+		}
+		plan.warnings = append(plan.warnings, warnings...)
+	}
+	return plan, nil
+}
+
+// managedBy returns the configuration the namespace has after applying desired: the fields set
+// in desired replace the current ones, and data keys are merged like the server does
+func (current *namespaceFileEntry) managedBy(desired *namespaceFileEntry) *namespaceFileEntry {
+	managed := *current
+	if desired.Description != "" {
+		managed.Description = desired.Description
+	}
+	if desired.OwnerEmail != "" {
+		managed.OwnerEmail = desired.OwnerEmail
+	}
+	if desired.Retention != "" {
+		if d, err := timestamp.ParseDurationDefaultDays(desired.Retention); err == nil {
+			managed.Retention = formatRetention(d)
+		} else {
+			managed.Retention = desired.Retention
+		}
+	}
+	if desired.Global != nil {
+		managed.Global = desired.Global
+	}
+	if desired.ActiveCluster != "" {
+		managed.ActiveCluster = desired.ActiveCluster
+	}
+	if len(desired.Clusters) > 0 {
+		managed.Clusters = desired.Clusters
+	}
+	if len(desired.Data) > 0 {
+		managed.Data = make(map[string]string, len(current.Data)+len(desired.Data))
+		for k, v := range current.Data {
+			managed.Data[k] = v
+		}
+		for k, v := range desired.Data {
+			managed.Data[k] = v
+		}
+	}
+	if desired.HistoryArchival != nil {
+		managed.HistoryArchival = desired.HistoryArchival.withURI(current.HistoryArchival)
+	}
+	if desired.VisibilityArchival != nil {
+		managed.VisibilityArchival = desired.VisibilityArchival.withURI(current.VisibilityArchival)
+	}
+	return &managed
+}
+
+// withURI keeps the current archival URI if none is given
+func (a *namespaceFileArchival) withURI(current *namespaceFileArchival) *namespaceFileArchival {
+	out := *a
+	if out.URI == "" && current != nil {
+		out.URI = current.URI
+	}
+	return &out
+}
+
+func registerNamespaceFromFile(c *cli.Context, client workflowservice.WorkflowServiceClient, desired *namespaceFileEntry) error {
+	retention := defaultNamespaceRetention
+	if desired.Retention != "" {
+		var err error
+		if retention, err = timestamp.ParseDurationDefaultDays(desired.Retention); err != nil {
+			return fmt.Errorf("namespace %s: retention format is invalid: %w", desired.Name, err)
+		}
+	}
+	var clusters []*replicationpb.ClusterReplicationConfig
+	for _, name := range desired.Clusters {
+		clusters = append(clusters, &replicationpb.ClusterReplicationConfig{ClusterName: name})
+	}
+	historyState, historyURI, err := desired.HistoryArchival.toConfig()
+	if err != nil {
+		return fmt.Errorf("namespace %s: %w", desired.Name, err)
+	}
+	visibilityState, visibilityURI, err := desired.VisibilityArchival.toConfig()
+	if err != nil {
+		return fmt.Errorf("namespace %s: %w", desired.Name, err)
+	}
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+	_, err = client.RegisterNamespace(ctx, &workflowservice.RegisterNamespaceRequest{
+		Namespace:                        desired.Name,
+		Description:                      desired.Description,
+		OwnerEmail:                       desired.OwnerEmail,
+		Data:                             desired.Data,
+		WorkflowExecutionRetentionPeriod: &retention,
+		Clusters:                         clusters,
+		ActiveClusterName:                desired.ActiveCluster,
+		HistoryArchivalState:             historyState,
+		HistoryArchivalUri:               historyURI,
+		VisibilityArchivalState:          visibilityState,
+		VisibilityArchivalUri:            visibilityURI,
+		IsGlobalNamespace:                desired.Global != nil && *desired.Global,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to register namespace %s: %w", desired.Name, err)
+	}
+	fmt.Printf("Namespace %s successfully registered.\n", desired.Name)
+	return nil
+}
+
+// updateNamespaceFromFile applies the changes of a plan. The server doesn't allow promoting a namespace
+// or changing its active cluster together with other changes, so those are sent as separate requests.
+func updateNamespaceFromFile(c *cli.Context, client workflowservice.WorkflowServiceClient, plan *namespacePlan) error {
+	desired, current := plan.desired, plan.current
+	managed := current.managedBy(desired)
+	var requests []*workflowservice.UpdateNamespaceRequest
+
+	if *managed.Global && !*current.Global {
+		requests = append(requests, &workflowservice.UpdateNamespaceRequest{
+			Namespace:        desired.Name,
+			PromoteNamespace: true,
+		})
+	}
+
+	activeCluster := managed.ActiveCluster
+	managed.ActiveCluster, managed.Global = current.ActiveCluster, current.Global
+	if !jsonEqual(managed, current) {
+		retention, err := timestamp.ParseDurationDefaultDays(managed.Retention)
+		if err != nil {
+			return fmt.Errorf("namespace %s: retention format is invalid: %w", desired.Name, err)
+		}
+		historyState, historyURI, err := managed.HistoryArchival.toConfig()
+		if err != nil {
+			return fmt.Errorf("namespace %s: %w", desired.Name, err)
+		}
+		visibilityState, visibilityURI, err := managed.VisibilityArchival.toConfig()
+		if err != nil {
+			return fmt.Errorf("namespace %s: %w", desired.Name, err)
+		}
+		request := &workflowservice.UpdateNamespaceRequest{
+			Namespace: desired.Name,
+			UpdateInfo: &namespacepb.UpdateNamespaceInfo{
+				Description: managed.Description,
+				OwnerEmail:  managed.OwnerEmail,
+				Data:        desired.Data,
+			},
+			Config: &namespacepb.NamespaceConfig{
+				WorkflowExecutionRetentionTtl: &retention,
+				HistoryArchivalState:          historyState,
+				HistoryArchivalUri:            historyURI,
+				VisibilityArchivalState:       visibilityState,
+				VisibilityArchivalUri:         visibilityURI,
+			},
+		}
+		if !jsonEqual(managed.Clusters, current.Clusters) {
+			request.ReplicationConfig = &replicationpb.NamespaceReplicationConfig{}
+			for _, name := range managed.Clusters {
+				request.ReplicationConfig.Clusters = append(request.ReplicationConfig.Clusters, &replicationpb.ClusterReplicationConfig{ClusterName: name})
+			}
+		}
+		requests = append(requests, request)
+	}
+
+	if activeCluster != current.ActiveCluster {
+		requests = append(requests, &workflowservice.UpdateNamespaceRequest{
+			Namespace:         desired.Name,
+			ReplicationConfig: &replicationpb.NamespaceReplicationConfig{ActiveClusterName: activeCluster},
+		})
+	}
+
+	for _, request := range requests {
+		ctx, cancel := newContext(c)
+		_, err := client.UpdateNamespace(ctx, request)
+		cancel()
+		if err != nil {
+			return fmt.Errorf("unable to update namespace %s: %w", desired.Name, err)
+		}
+	}
+	fmt.Printf("Namespace %s successfully updated.\n", desired.Name)
+	return nil
+}
+
+func (a *namespaceFileArchival) toConfig() (enumspb.ArchivalState, string, error) {
+	if a == nil {
+		return enumspb.ARCHIVAL_STATE_UNSPECIFIED, "", nil
+	}
+	switch a.State {
+	case "enabled":
+		return enumspb.ARCHIVAL_STATE_ENABLED, a.URI, nil
+	case "disabled":
+		return enumspb.ARCHIVAL_STATE_DISABLED, a.URI, nil
+	default:
+		return 0, "", fmt.Errorf("invalid archival state %q, valid values are \"disabled\" and \"enabled\"", a.State)
+	}
+}
+
+// ExportNamespaces writes the configuration of namespaces in the format read by apply
+func ExportNamespaces(c *cli.Context) error {
+	client := cFactory.FrontendClient(c)
+
+	var namespaces []*workflowservice.DescribeNamespaceResponse
+	if c.Args().Len() == 0 {
+		var err error
+		if namespaces, err = getAllNamespaces(c, client); err != nil {
+			return err
+		}
+	}
+	for _, name := range c.Args().Slice() {
+		ctx, cancel := newContext(c)
+		resp, err := client.DescribeNamespace(ctx, &workflowservice.DescribeNamespaceRequest{Namespace: name})
+		cancel()
+		if err != nil {
+			return fmt.Errorf("unable to describe namespace %s: %w", name, err)
+		}
+		namespaces = append(namespaces, resp)
+	}
+
+	var file namespaceFile
+	for _, ns := range namespaces {
+		if ns.GetNamespaceInfo().GetState() == enumspb.NAMESPACE_STATE_DELETED {
+			continue
+		}
+		file.Namespaces = append(file.Namespaces, namespaceFileEntryFromDescribe(ns))
+	}
+	sort.Slice(file.Namespaces, func(i, j int) bool {
+		return file.Namespaces[i].Name < file.Namespaces[j].Name
+	})
+	return writeDefinition(c, "namespace", &file)
+}
+
+func readNamespaceFile(path string) (*namespaceFile, error) {
+	var file namespaceFile
+	if err := readDefinitionFile(path, "namespace", &file); err != nil {
+		return nil, err
+	}
+	names := make(map[string]bool)
+	for i, ns := range file.Namespaces {
+		if ns.Name == "" {
+			return nil, fmt.Errorf("namespace %d in the file has no name", i+1)
+		}
+		if names[ns.Name] {
+			return nil, fmt.Errorf("namespace %s is defined more than once", ns.Name)
+		}
+		names[ns.Name] = true
+		if ns.Retention != "" {
+			if _, err := timestamp.ParseDurationDefaultDays(ns.Retention); err != nil {
+				return nil, fmt.Errorf("namespace %s: retention format is invalid: %w", ns.Name, err)
+			}
+		}
+		for _, archival := range []*namespaceFileArchival{ns.HistoryArchival, ns.VisibilityArchival} {
+			if _, _, err := archival.toConfig(); err != nil {
+				return nil, fmt.Errorf("namespace %s: %w", ns.Name, err)
+			}
+		}
+	}
+	return &file, nil
+}
+
+func namespaceFileEntryFromDescribe(resp *workflowservice.DescribeNamespaceResponse) *namespaceFileEntry {
+	global := resp.GetIsGlobalNamespace()
+	entry := &namespaceFileEntry{
+		Name:          resp.GetNamespaceInfo().GetName(),
+		Description:   resp.GetNamespaceInfo().GetDescription(),
+		OwnerEmail:    resp.GetNamespaceInfo().GetOwnerEmail(),
+		Retention:     formatRetention(timestamp.DurationValue(resp.GetConfig().GetWorkflowExecutionRetentionTtl())),
+		Global:        &global,
+		ActiveCluster: resp.GetReplicationConfig().GetActiveClusterName(),
+		Data:          resp.GetNamespaceInfo().GetData(),
+	}
+	for _, cluster := range resp.GetReplicationConfig().GetClusters() {
+		entry.Clusters = append(entry.Clusters, cluster.GetClusterName())
+	}
+	entry.HistoryArchival = namespaceFileArchivalFromConfig(resp.GetConfig().GetHistoryArchivalState(), resp.GetConfig().GetHistoryArchivalUri())
+	entry.VisibilityArchival = namespaceFileArchivalFromConfig(resp.GetConfig().GetVisibilityArchivalState(), resp.GetConfig().GetVisibilityArchivalUri())
+	return entry
+}
+
+func namespaceFileEntryFromRegister(request *workflowservice.RegisterNamespaceRequest) *namespaceFileEntry {
+	global := request.GetIsGlobalNamespace()
+	entry := &namespaceFileEntry{
+		Name:               request.GetNamespace(),
+		Description:        request.GetDescription(),
+		OwnerEmail:         request.GetOwnerEmail(),
+		Retention:          formatRetention(timestamp.DurationValue(request.GetWorkflowExecutionRetentionPeriod())),
+		Global:             &global,
+		ActiveCluster:      request.GetActiveClusterName(),
+		Data:               request.GetData(),
+		HistoryArchival:    namespaceFileArchivalFromConfig(request.GetHistoryArchivalState(), request.GetHistoryArchivalUri()),
+		VisibilityArchival: namespaceFileArchivalFromConfig(request.GetVisibilityArchivalState(), request.GetVisibilityArchivalUri()),
+	}
+	for _, cluster := range request.GetClusters() {
+		entry.Clusters = append(entry.Clusters, cluster.GetClusterName())
+	}
+	return entry
+}
+
+func namespaceFileArchivalFromConfig(state enumspb.ArchivalState, uri string) *namespaceFileArchival {
+	switch state {
+	case enumspb.ARCHIVAL_STATE_ENABLED:
+		return &namespaceFileArchival{State: "enabled", URI: uri}
+	case enumspb.ARCHIVAL_STATE_DISABLED:
+		return &namespaceFileArchival{State: "disabled", URI: uri}
+	}
+	return nil
+}
+
+// formatRetention formats whole days as e.g. 30d, which is how retention is usually given
+func formatRetention(d time.Duration) string {
+	day := 24 * time.Hour
+	if d > 0 && d%day == 0 {
+		return fmt.Sprintf("%dd", d/day)
+	}
+	return strings.TrimSpace(formatDuration(d))
+}
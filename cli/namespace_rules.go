@@ -0,0 +1,275 @@
+// The MIT License
+//
+// Copyright (c) 2022 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cli
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/temporalio/tctl-kit/pkg/color"
+	"github.com/temporalio/tctl-kit/pkg/output"
+	"github.com/urfave/cli/v2"
+	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/server/common/primitives/timestamp"
+)
+
+// namespaceRules are the conventions namespaces must follow, read from the file given with --namespace-rules.
+// Setting the flag per environment, e.g. `tctl config set namespace-rules prod-rules.yaml`, gives each environment its rules.
+type namespaceRules struct {
+	// NamePattern is a regular expression the whole namespace name must match
+	NamePattern        string                        `yaml:"namePattern,omitempty"`
+	Retention          *namespaceRetentionRule       `yaml:"retention,omitempty"`
+	RequireDescription bool                          `yaml:"requireDescription,omitempty"`
+	RequireOwnerEmail  bool                          `yaml:"requireOwnerEmail,omitempty"`
+	RequireGlobal      bool                          `yaml:"requireGlobal,omitempty"`
+	HistoryArchival    string                        `yaml:"historyArchival,omitempty"`
+	VisibilityArchival string                        `yaml:"visibilityArchival,omitempty"`
+	Data               map[string]*namespaceDataRule `yaml:"data,omitempty"`
+
+	name     *regexp.Regexp
+	min, max time.Duration
+}
+
+type namespaceRetentionRule struct {
+	Min string `yaml:"min,omitempty"`
+	Max string `yaml:"max,omitempty"`
+}
+
+type namespaceDataRule struct {
+	Required bool `yaml:"required,omitempty"`
+	// Values, if not empty, are the only values the key may have
+	Values []string `yaml:"values,omitempty"`
+}
+
+// loadNamespaceRules reads the rules file if one is set. The keys set with SetRequiredNamespaceDataKeys are always required.
+func loadNamespaceRules(c *cli.Context) (*namespaceRules, error) {
+	return readNamespaceRules(c.String(FlagNamespaceRules))
+}
+
+func readNamespaceRules(path string) (*namespaceRules, error) {
+	rules := &namespaceRules{}
+	if path != "" {
+		if err := readDefinitionFile(path, "namespace rules", rules); err != nil {
+			return nil, err
+		}
+	}
+
+	var err error
+	if rules.NamePattern != "" {
+		if rules.name, err = regexp.Compile("^(?:" + rules.NamePattern + ")$"); err != nil {
+			return nil, fmt.Errorf("invalid namespace name pattern: %w", err)
+		}
+	}
+	if rules.Retention != nil {
+		if rules.Retention.Min != "" {
+			if rules.min, err = timestamp.ParseDurationDefaultDays(rules.Retention.Min); err != nil {
+				return nil, fmt.Errorf("invalid minimum retention: %w", err)
+			}
+		}
+		if rules.Retention.Max != "" {
+			if rules.max, err = timestamp.ParseDurationDefaultDays(rules.Retention.Max); err != nil {
+				return nil, fmt.Errorf("invalid maximum retention: %w", err)
+			}
+		}
+	}
+	for _, state := range []string{rules.HistoryArchival, rules.VisibilityArchival} {
+		if state != "" && state != "enabled" && state != "disabled" {
+			return nil, fmt.Errorf("invalid archival state %q in namespace rules, valid values are \"disabled\" and \"enabled\"", state)
+		}
+	}
+	for _, key := range requiredNamespaceDataKeys {
+		if rules.Data == nil {
+			rules.Data = make(map[string]*namespaceDataRule)
+		}
+		if rules.Data[key] == nil {
+			rules.Data[key] = &namespaceDataRule{}
+		}
+		rules.Data[key].Required = true
+	}
+	return rules, nil
+}
+
+// violations returns the rules the namespace configuration doesn't follow
+func (r *namespaceRules) violations(ns *namespaceFileEntry) []string {
+	var violations []string
+	if r.name != nil && !r.name.MatchString(ns.Name) {
+		violations = append(violations, fmt.Sprintf("name doesn't match %s", r.NamePattern))
+	}
+	if r.min > 0 || r.max > 0 {
+		retention, err := timestamp.ParseDurationDefaultDays(ns.Retention)
+		switch {
+		case err != nil:
+			violations = append(violations, fmt.Sprintf("retention %q is invalid", ns.Retention))
+		case r.min > 0 && retention < r.min:
+			violations = append(violations, fmt.Sprintf("retention %s is less than %s", ns.Retention, r.Retention.Min))
+		case r.max > 0 && retention > r.max:
+			violations = append(violations, fmt.Sprintf("retention %s is more than %s", ns.Retention, r.Retention.Max))
+		}
+	}
+	if r.RequireDescription && ns.Description == "" {
+		violations = append(violations, "description is required")
+	}
+	if r.RequireOwnerEmail && ns.OwnerEmail == "" {
+		violations = append(violations, "owner email is required")
+	}
+	if r.RequireGlobal && (ns.Global == nil || !*ns.Global) {
+		violations = append(violations, "namespace must be global")
+	}
+	if r.HistoryArchival != "" && archivalRuleState(ns.HistoryArchival) != r.HistoryArchival {
+		violations = append(violations, fmt.Sprintf("history archival must be %s", r.HistoryArchival))
+	}
+	if r.VisibilityArchival != "" && archivalRuleState(ns.VisibilityArchival) != r.VisibilityArchival {
+		violations = append(violations, fmt.Sprintf("visibility archival must be %s", r.VisibilityArchival))
+	}
+
+	keys := make([]string, 0, len(r.Data))
+	for key := range r.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		rule := r.Data[key]
+		value, ok := ns.Data[key]
+		if !ok {
+			if rule.Required {
+				violations = append(violations, fmt.Sprintf("missing namespace data key %s", key))
+			}
+			continue
+		}
+		if len(rule.Values) > 0 && !isAllowedValue(rule.Values, value) {
+			violations = append(violations, fmt.Sprintf("namespace data key %s is %q, allowed values are %s", key, value, strings.Join(rule.Values, ", ")))
+		}
+	}
+	return violations
+}
+
+// check returns an error listing the rules the namespace configuration doesn't follow
+func (r *namespaceRules) check(ns *namespaceFileEntry) error {
+	violations := r.violations(ns)
+	if len(violations) == 0 {
+		return nil
+	}
+	return fmt.Errorf("namespace %s doesn't follow the namespace rules: %s", ns.Name, strings.Join(violations, "; "))
+}
+
+// checkUpdate checks a change to an existing namespace. Violations the namespace already had are returned
+// as warnings rather than errors, so that namespaces older than the rules can still be changed.
+// `namespace lint` is the way to enforce the rules on existing namespaces.
+func (r *namespaceRules) checkUpdate(current *namespaceFileEntry, updated *namespaceFileEntry) ([]string, error) {
+	existing := make(map[string]bool)
+	for _, violation := range r.violations(current) {
+		existing[violation] = true
+	}
+	var warnings, violations []string
+	for _, violation := range r.violations(updated) {
+		if existing[violation] {
+			warnings = append(warnings, fmt.Sprintf("doesn't follow the namespace rules: %s", violation))
+		} else {
+			violations = append(violations, violation)
+		}
+	}
+	if len(violations) > 0 {
+		return nil, fmt.Errorf("namespace %s doesn't follow the namespace rules: %s", updated.Name, strings.Join(violations, "; "))
+	}
+	return warnings, nil
+}
+
+func archivalRuleState(archival *namespaceFileArchival) string {
+	if archival == nil {
+		return "disabled"
+	}
+	return archival.State
+}
+
+func isAllowedValue(allowed []string, value string) bool {
+	for _, v := range allowed {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+type namespaceRuleViolation struct {
+	Namespace string
+	Violation string
+}
+
+// LintNamespaces checks existing namespaces against the namespace rules
+func LintNamespaces(c *cli.Context) error {
+	if c.String(FlagNamespaceRules) == "" && len(requiredNamespaceDataKeys) == 0 {
+		return fmt.Errorf("no namespace rules, set them with --%s", FlagNamespaceRules)
+	}
+	rules, err := loadNamespaceRules(c)
+	if err != nil {
+		return err
+	}
+	client := cFactory.FrontendClient(c)
+
+	var namespaces []*workflowservice.DescribeNamespaceResponse
+	if c.Args().Len() == 0 {
+		if namespaces, err = getAllNamespaces(c, client); err != nil {
+			return err
+		}
+	}
+	for _, name := range c.Args().Slice() {
+		ctx, cancel := newContext(c)
+		resp, err := client.DescribeNamespace(ctx, &workflowservice.DescribeNamespaceRequest{Namespace: name})
+		cancel()
+		if err != nil {
+			return fmt.Errorf("unable to describe namespace %s: %w", name, err)
+		}
+		namespaces = append(namespaces, resp)
+	}
+
+	var items []interface{}
+	failed := 0
+	for _, ns := range namespaces {
+		entry := namespaceFileEntryFromDescribe(ns)
+		violations := rules.violations(entry)
+		if len(violations) > 0 {
+			failed++
+		}
+		for _, violation := range violations {
+			items = append(items, namespaceRuleViolation{Namespace: entry.Name, Violation: violation})
+		}
+	}
+	if failed == 0 {
+		fmt.Println(color.Green(c, "All %d namespaces follow the namespace rules", len(namespaces)))
+		return nil
+	}
+
+	po := &output.PrintOptions{
+		Fields:      []string{"Namespace", "Violation"},
+		ForceFields: true,
+	}
+	if err := output.PrintItems(c, items, po); err != nil {
+		return err
+	}
+	return fmt.Errorf("%d of %d namespaces don't follow the namespace rules", failed, len(namespaces))
+}
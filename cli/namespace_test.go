@@ -25,15 +25,20 @@
 package cli
 
 import (
+	"context"
+	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/golang/mock/gomock"
+	enumspb "go.temporal.io/api/enums/v1"
 	namespacepb "go.temporal.io/api/namespace/v1"
 	"go.temporal.io/api/operatorservice/v1"
 	replicationpb "go.temporal.io/api/replication/v1"
 	"go.temporal.io/api/serviceerror"
 	"go.temporal.io/api/workflowservice/v1"
 	"go.temporal.io/server/common/primitives/timestamp"
+	"google.golang.org/grpc"
 )
 
 func (s *cliAppSuite) TestNamespaceRegister_LocalNamespace() {
@@ -169,20 +174,364 @@ func (s *cliAppSuite) TestNamespaceDescribe_Failed() {
 	s.Equal(1, errorCode)
 }
 
+func (s *cliAppSuite) expectNamespaceDeleteImpact() {
+	s.frontendClient.EXPECT().DescribeNamespace(gomock.Any(), gomock.Any()).Return(describeNamespaceResponseServer, nil)
+	s.frontendClient.EXPECT().CountWorkflowExecutions(gomock.Any(), gomock.Any()).Return(&workflowservice.CountWorkflowExecutionsResponse{Count: 3}, nil).Times(2)
+	s.frontendClient.EXPECT().ListSchedules(gomock.Any(), gomock.Any()).Return(&workflowservice.ListSchedulesResponse{}, nil)
+}
+
 func (s *cliAppSuite) TestNamespaceDelete() {
+	s.expectNamespaceDeleteImpact()
 	s.operatorClient.EXPECT().DeleteNamespace(gomock.Any(), &operatorservice.DeleteNamespaceRequest{Namespace: cliTestNamespace}).Return(&operatorservice.DeleteNamespaceResponse{}, nil)
 	err := s.app.Run([]string{"", "namespace", "delete", "--yes", cliTestNamespace})
 	s.Nil(err)
 }
 
+func (s *cliAppSuite) TestNamespaceDelete_MixedCaseName() {
+	stdin := os.Stdin
+	defer func() { os.Stdin = stdin }()
+	r, w, err := os.Pipe()
+	s.NoError(err)
+	defer r.Close()
+	_, err = w.WriteString("Legacy_Payments\n")
+	s.NoError(err)
+	s.NoError(w.Close())
+	os.Stdin = r
+
+	s.expectNamespaceDeleteImpact()
+	s.operatorClient.EXPECT().DeleteNamespace(gomock.Any(), &operatorservice.DeleteNamespaceRequest{Namespace: "Legacy_Payments"}).Return(&operatorservice.DeleteNamespaceResponse{}, nil)
+	err = s.app.Run([]string{"", "namespace", "delete", "Legacy_Payments"})
+	s.Nil(err)
+}
+
 func (s *cliAppSuite) TestNamespaceDelete_NamespaceNotExist() {
+	s.expectNamespaceDeleteImpact()
 	s.operatorClient.EXPECT().DeleteNamespace(gomock.Any(), gomock.Any()).Return(nil, serviceerror.NewNamespaceNotFound("missing-namespace"))
 	errorCode := s.RunWithExitCode([]string{"", "namespace", "delete", "--yes", cliTestNamespace})
 	s.Equal(1, errorCode)
 }
 
 func (s *cliAppSuite) TestNamespaceDelete_Failed() {
+	s.expectNamespaceDeleteImpact()
 	s.operatorClient.EXPECT().DeleteNamespace(gomock.Any(), gomock.Any()).Return(nil, serviceerror.NewInvalidArgument("faked error"))
 	errorCode := s.RunWithExitCode([]string{"", "namespace", "delete", "--yes", cliTestNamespace})
 	s.Equal(1, errorCode)
 }
+
+func (s *cliAppSuite) TestNamespaceApply() {
+	path := s.writeTempFile("namespaces.yaml", `
+namespaces:
+  - name: test-namespace
+    description: updated desc
+    retention: 72h
+    activeCluster: standby
+    data:
+      team: infra
+  - name: new-namespace
+    ownerEmail: new@temporal.io
+    retention: 7d
+`)
+	s.frontendClient.EXPECT().DescribeNamespace(gomock.Any(), &workflowservice.DescribeNamespaceRequest{Namespace: "test-namespace"}).Return(describeNamespaceResponseServer, nil)
+	s.frontendClient.EXPECT().DescribeNamespace(gomock.Any(), &workflowservice.DescribeNamespaceRequest{Namespace: "new-namespace"}).Return(nil, serviceerror.NewNamespaceNotFound("new-namespace"))
+
+	var updates []*workflowservice.UpdateNamespaceRequest
+	s.frontendClient.EXPECT().UpdateNamespace(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, request *workflowservice.UpdateNamespaceRequest, _ ...grpc.CallOption) (*workflowservice.UpdateNamespaceResponse, error) {
+			updates = append(updates, request)
+			return &workflowservice.UpdateNamespaceResponse{}, nil
+		}).Times(2)
+	s.frontendClient.EXPECT().RegisterNamespace(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, request *workflowservice.RegisterNamespaceRequest, _ ...grpc.CallOption) (*workflowservice.RegisterNamespaceResponse, error) {
+			s.Equal("new-namespace", request.Namespace)
+			s.Equal("new@temporal.io", request.OwnerEmail)
+			s.Equal(7*24*time.Hour, timestamp.DurationValue(request.WorkflowExecutionRetentionPeriod))
+			return &workflowservice.RegisterNamespaceResponse{}, nil
+		})
+
+	err := s.app.Run([]string{"", "namespace", "apply", "--yes", "-f", path})
+	s.NoError(err)
+
+	// the active cluster can't be changed with other fields, so it is updated separately
+	s.Len(updates, 2)
+	s.Equal("updated desc", updates[0].UpdateInfo.Description)
+	s.Equal("test@uber.com", updates[0].UpdateInfo.OwnerEmail)
+	s.Equal(map[string]string{"team": "infra"}, updates[0].UpdateInfo.Data)
+	s.Equal(3*24*time.Hour, timestamp.DurationValue(updates[0].Config.WorkflowExecutionRetentionTtl))
+	s.Nil(updates[0].ReplicationConfig)
+	s.Equal("standby", updates[1].ReplicationConfig.ActiveClusterName)
+	s.Nil(updates[1].UpdateInfo)
+}
+
+func (s *cliAppSuite) TestNamespaceApply_GlobalToLocal() {
+	path := s.writeTempFile("namespaces.yaml", `
+namespaces:
+  - name: test-namespace
+    global: false
+`)
+	resp := *describeNamespaceResponseServer
+	resp.IsGlobalNamespace = true
+	s.frontendClient.EXPECT().DescribeNamespace(gomock.Any(), gomock.Any()).Return(&resp, nil)
+	errorCode := s.RunWithExitCode([]string{"", "namespace", "apply", "--yes", "-f", path})
+	s.Equal(1, errorCode)
+}
+
+func (s *cliAppSuite) TestNamespaceExport() {
+	s.frontendClient.EXPECT().DescribeNamespace(gomock.Any(), gomock.Any()).Return(describeNamespaceResponseServer, nil).Times(2)
+
+	exported := filepath.Join(s.T().TempDir(), "exported.yaml")
+	err := s.app.Run([]string{"", "namespace", "export", "--output-filename", exported, cliTestNamespace})
+	s.NoError(err)
+
+	file, err := readNamespaceFile(exported)
+	s.NoError(err)
+	s.Len(file.Namespaces, 1)
+	s.Equal("3d", file.Namespaces[0].Retention)
+	s.Equal([]string{"active", "standby"}, file.Namespaces[0].Clusters)
+
+	// applying the export leaves the namespace as it is
+	err = s.app.Run([]string{"", "namespace", "apply", "--yes", "-f", exported})
+	s.NoError(err)
+}
+
+func globalNamespaceResponse(name, activeCluster string, failoverVersion int64) *workflowservice.DescribeNamespaceResponse {
+	return &workflowservice.DescribeNamespaceResponse{
+		NamespaceInfo:     &namespacepb.NamespaceInfo{Name: name},
+		IsGlobalNamespace: true,
+		FailoverVersion:   failoverVersion,
+		ReplicationConfig: &replicationpb.NamespaceReplicationConfig{
+			ActiveClusterName: activeCluster,
+			Clusters: []*replicationpb.ClusterReplicationConfig{
+				{ClusterName: "active"},
+				{ClusterName: "standby"},
+			},
+		},
+	}
+}
+
+var listClustersResponse = &operatorservice.ListClustersResponse{
+	Clusters: []*operatorservice.ClusterMetadata{
+		{ClusterName: "active", Address: "active:7233", IsConnectionEnabled: true},
+		{ClusterName: "standby", Address: "standby:7233", IsConnectionEnabled: true},
+		{ClusterName: "disconnected", Address: "disconnected:7233"},
+	},
+}
+
+func (s *cliAppSuite) TestNamespaceFailover() {
+	defer func(interval time.Duration) { failoverPollInterval = interval }(failoverPollInterval)
+	failoverPollInterval = time.Millisecond
+
+	s.operatorClient.EXPECT().ListClusters(gomock.Any(), gomock.Any()).Return(listClustersResponse, nil)
+	gomock.InOrder(
+		s.frontendClient.EXPECT().DescribeNamespace(gomock.Any(), gomock.Any()).Return(globalNamespaceResponse(cliTestNamespace, "active", 1), nil),
+		s.frontendClient.EXPECT().UpdateNamespace(gomock.Any(), &workflowservice.UpdateNamespaceRequest{
+			Namespace:         cliTestNamespace,
+			ReplicationConfig: &replicationpb.NamespaceReplicationConfig{ActiveClusterName: "standby"},
+		}).Return(&workflowservice.UpdateNamespaceResponse{}, nil),
+		s.frontendClient.EXPECT().DescribeNamespace(gomock.Any(), gomock.Any()).Return(globalNamespaceResponse(cliTestNamespace, "active", 1), nil),
+		s.frontendClient.EXPECT().DescribeNamespace(gomock.Any(), gomock.Any()).Return(globalNamespaceResponse(cliTestNamespace, "standby", 12), nil),
+	)
+
+	err := s.app.Run([]string{"", "namespace", "failover", "--to", "standby", "--yes", cliTestNamespace})
+	s.NoError(err)
+}
+
+func (s *cliAppSuite) TestNamespaceFailover_PreChecks() {
+	local := globalNamespaceResponse(cliTestNamespace, "active", 1)
+	local.IsGlobalNamespace = false
+
+	for _, tc := range []struct {
+		name string
+		ns   *workflowservice.DescribeNamespaceResponse
+		to   string
+	}{
+		{name: "local namespace", ns: local, to: "standby"},
+		{name: "cluster not in namespace", ns: globalNamespaceResponse(cliTestNamespace, "active", 1), to: "disconnected"},
+		{name: "unknown cluster", ns: globalNamespaceResponse(cliTestNamespace, "active", 1), to: "missing"},
+	} {
+		s.frontendClient.EXPECT().DescribeNamespace(gomock.Any(), gomock.Any()).Return(tc.ns, nil)
+		s.operatorClient.EXPECT().ListClusters(gomock.Any(), gomock.Any()).Return(listClustersResponse, nil)
+		errorCode := s.RunWithExitCode([]string{"", "namespace", "failover", "--to", tc.to, "--yes", cliTestNamespace})
+		s.Equal(1, errorCode, tc.name)
+	}
+}
+
+func (s *cliAppSuite) TestNamespaceFailover_Batch() {
+	defer func(interval time.Duration) { failoverPollInterval = interval }(failoverPollInterval)
+	failoverPollInterval = time.Millisecond
+
+	s.frontendClient.EXPECT().ListNamespaces(gomock.Any(), gomock.Any()).Return(&workflowservice.ListNamespacesResponse{
+		Namespaces: []*workflowservice.DescribeNamespaceResponse{
+			globalNamespaceResponse("payments-a", "active", 1),
+			globalNamespaceResponse("payments-b", "standby", 2),
+			globalNamespaceResponse("orders", "active", 1),
+		},
+	}, nil)
+	s.operatorClient.EXPECT().ListClusters(gomock.Any(), gomock.Any()).Return(listClustersResponse, nil)
+	// payments-b is already active in standby, orders doesn't match
+	s.frontendClient.EXPECT().UpdateNamespace(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, request *workflowservice.UpdateNamespaceRequest, _ ...grpc.CallOption) (*workflowservice.UpdateNamespaceResponse, error) {
+			s.Equal("payments-a", request.Namespace)
+			return &workflowservice.UpdateNamespaceResponse{}, nil
+		})
+	s.frontendClient.EXPECT().DescribeNamespace(gomock.Any(), gomock.Any()).Return(globalNamespaceResponse("payments-a", "standby", 12), nil)
+
+	err := s.app.Run([]string{"", "namespace", "failover", "--to", "standby", "--batch", "payments-*", "--yes"})
+	s.NoError(err)
+}
+
+func (s *cliAppSuite) TestNamespaceDelete_Wait() {
+	defer func(interval time.Duration) { deleteNamespacePollInterval = interval }(deleteNamespacePollInterval)
+	deleteNamespacePollInterval = time.Millisecond
+
+	s.frontendClient.EXPECT().DescribeNamespace(gomock.Any(), gomock.Any()).Return(describeNamespaceResponseServer, nil)
+	s.frontendClient.EXPECT().CountWorkflowExecutions(gomock.Any(), &workflowservice.CountWorkflowExecutionsRequest{Namespace: cliTestNamespace, Query: "ExecutionStatus = 'Running'"}).Return(&workflowservice.CountWorkflowExecutionsResponse{Count: 1}, nil)
+	s.frontendClient.EXPECT().CountWorkflowExecutions(gomock.Any(), &workflowservice.CountWorkflowExecutionsRequest{Namespace: cliTestNamespace}).Return(nil, serviceerror.NewUnimplemented("count"))
+	s.frontendClient.EXPECT().ListSchedules(gomock.Any(), gomock.Any()).Return(&workflowservice.ListSchedulesResponse{}, nil)
+	s.operatorClient.EXPECT().DeleteNamespace(gomock.Any(), gomock.Any()).Return(&operatorservice.DeleteNamespaceResponse{DeletedNamespace: "cli-test-namespace-deleted-1234"}, nil)
+
+	deleted := &workflowservice.DescribeNamespaceRequest{Namespace: "cli-test-namespace-deleted-1234"}
+	gomock.InOrder(
+		s.frontendClient.EXPECT().DescribeNamespace(gomock.Any(), deleted).Return(describeNamespaceResponseServer, nil),
+		s.frontendClient.EXPECT().CountWorkflowExecutions(gomock.Any(), gomock.Any()).Return(&workflowservice.CountWorkflowExecutionsResponse{Count: 2}, nil),
+		s.frontendClient.EXPECT().DescribeNamespace(gomock.Any(), deleted).Return(nil, serviceerror.NewNamespaceNotFound("cli-test-namespace-deleted-1234")),
+	)
+
+	err := s.app.Run([]string{"", "namespace", "delete", "--yes", "--wait", cliTestNamespace})
+	s.NoError(err)
+}
+
+func (s *cliAppSuite) TestNamespaceDelete_WaitTimeout() {
+	defer func(interval time.Duration) { deleteNamespacePollInterval = interval }(deleteNamespacePollInterval)
+	deleteNamespacePollInterval = time.Millisecond
+
+	s.expectNamespaceDeleteImpact()
+	s.operatorClient.EXPECT().DeleteNamespace(gomock.Any(), gomock.Any()).Return(&operatorservice.DeleteNamespaceResponse{DeletedNamespace: "cli-test-namespace-deleted-1234"}, nil)
+	// the deleted namespace never disappears
+	deleted := &workflowservice.DescribeNamespaceRequest{Namespace: "cli-test-namespace-deleted-1234"}
+	s.frontendClient.EXPECT().DescribeNamespace(gomock.Any(), deleted).Return(describeNamespaceResponseServer, nil).MinTimes(1)
+	s.frontendClient.EXPECT().CountWorkflowExecutions(gomock.Any(), gomock.Any()).Return(&workflowservice.CountWorkflowExecutionsResponse{Count: 2}, nil).MinTimes(1)
+
+	errorCode := s.RunWithExitCode([]string{"", "namespace", "delete", "--yes", "--wait", "--wait-timeout", "10ms", cliTestNamespace})
+	s.Equal(1, errorCode)
+}
+
+func (s *cliAppSuite) TestNamespaceDelete_DescribeNotExist() {
+	s.frontendClient.EXPECT().DescribeNamespace(gomock.Any(), gomock.Any()).Return(nil, serviceerror.NewNamespaceNotFound("missing-namespace"))
+	errorCode := s.RunWithExitCode([]string{"", "namespace", "delete", "--yes", cliTestNamespace})
+	s.Equal(1, errorCode)
+}
+
+const testNamespaceRules = `
+namePattern: '[a-z][a-z0-9-]*'
+retention:
+  min: 1d
+  max: 30d
+requireOwnerEmail: true
+historyArchival: enabled
+data:
+  team:
+    required: true
+    values: [payments, orders]
+  tier:
+    values: [gold, silver]
+`
+
+func (s *cliAppSuite) TestNamespaceRules() {
+	rules, err := readNamespaceRules(s.writeTempFile("rules.yaml", testNamespaceRules))
+	s.NoError(err)
+
+	valid := &namespaceFileEntry{
+		Name:            "payments-prod",
+		OwnerEmail:      "payments@temporal.io",
+		Retention:       "7d",
+		Data:            map[string]string{"team": "payments", "tier": "gold"},
+		HistoryArchival: &namespaceFileArchival{State: "enabled", URI: "s3://archive"},
+	}
+	s.Empty(rules.violations(valid))
+
+	invalid := &namespaceFileEntry{
+		Name:      "Payments_Prod",
+		Retention: "90d",
+		Data:      map[string]string{"tier": "bronze"},
+	}
+	s.Equal([]string{
+		"name doesn't match [a-z][a-z0-9-]*",
+		"retention 90d is more than 30d",
+		"owner email is required",
+		"history archival must be enabled",
+		"missing namespace data key team",
+		"namespace data key tier is \"bronze\", allowed values are gold, silver",
+	}, rules.violations(invalid))
+}
+
+func (s *cliAppSuite) TestNamespaceRegister_Rules() {
+	rules := s.writeTempFile("rules.yaml", testNamespaceRules)
+	errorCode := s.RunWithExitCode([]string{"", "namespace", "register", "--namespace-rules", rules, "--retention", "7", "--data", "team=payments", "payments-prod"})
+	s.Equal(1, errorCode)
+
+	s.frontendClient.EXPECT().RegisterNamespace(gomock.Any(), gomock.Any()).Return(nil, nil)
+	err := s.app.Run([]string{"", "namespace", "register", "--namespace-rules", rules, "--retention", "7", "--data", "team=payments",
+		"--email", "payments@temporal.io", "--history-archival-state", "enabled", "payments-prod"})
+	s.NoError(err)
+}
+
+func (s *cliAppSuite) TestNamespaceUpdate_Rules() {
+	resp := &workflowservice.DescribeNamespaceResponse{
+		NamespaceInfo: &namespacepb.NamespaceInfo{
+			Name:       "payments-prod",
+			OwnerEmail: "payments@temporal.io",
+			Data:       map[string]string{"team": "payments"},
+		},
+		Config: &namespacepb.NamespaceConfig{
+			WorkflowExecutionRetentionTtl: timestamp.DurationPtr(7 * 24 * time.Hour),
+			HistoryArchivalState:          enumspb.ARCHIVAL_STATE_ENABLED,
+		},
+	}
+	s.frontendClient.EXPECT().DescribeNamespace(gomock.Any(), gomock.Any()).Return(resp, nil).Times(2)
+	rules := s.writeTempFile("rules.yaml", testNamespaceRules)
+
+	errorCode := s.RunWithExitCode([]string{"", "namespace", "update", "--namespace-rules", rules, "--retention", "60", "payments-prod"})
+	s.Equal(1, errorCode)
+
+	s.frontendClient.EXPECT().UpdateNamespace(gomock.Any(), gomock.Any()).Return(nil, nil)
+	err := s.app.Run([]string{"", "namespace", "update", "--namespace-rules", rules, "--retention", "14", "payments-prod"})
+	s.NoError(err)
+}
+
+func (s *cliAppSuite) TestNamespaceUpdate_RulesLegacyNamespace() {
+	// the name doesn't follow the rules, which doesn't block updates that don't add violations
+	resp := &workflowservice.DescribeNamespaceResponse{
+		NamespaceInfo: &namespacepb.NamespaceInfo{
+			Name:       "Legacy_Payments",
+			OwnerEmail: "payments@temporal.io",
+			Data:       map[string]string{"team": "payments"},
+		},
+		Config: &namespacepb.NamespaceConfig{
+			WorkflowExecutionRetentionTtl: timestamp.DurationPtr(60 * 24 * time.Hour),
+			HistoryArchivalState:          enumspb.ARCHIVAL_STATE_ENABLED,
+		},
+	}
+	s.frontendClient.EXPECT().DescribeNamespace(gomock.Any(), gomock.Any()).Return(resp, nil).Times(2)
+	s.frontendClient.EXPECT().UpdateNamespace(gomock.Any(), gomock.Any()).Return(nil, nil)
+	rules := s.writeTempFile("rules.yaml", testNamespaceRules)
+
+	err := s.app.Run([]string{"", "namespace", "update", "--namespace-rules", rules, "--retention", "14", "Legacy_Payments"})
+	s.NoError(err)
+
+	// applying a file that doesn't change the namespace doesn't check it
+	path := s.writeTempFile("namespaces.yaml", `
+namespaces:
+  - name: Legacy_Payments
+    ownerEmail: payments@temporal.io
+`)
+	err = s.app.Run([]string{"", "namespace", "apply", "--namespace-rules", rules, "--yes", "-f", path})
+	s.NoError(err)
+}
+
+func (s *cliAppSuite) TestNamespaceLint() {
+	s.frontendClient.EXPECT().ListNamespaces(gomock.Any(), gomock.Any()).Return(&workflowservice.ListNamespacesResponse{
+		Namespaces: []*workflowservice.DescribeNamespaceResponse{describeNamespaceResponseServer},
+	}, nil)
+	errorCode := s.RunWithExitCode([]string{"", "namespace", "lint", "--namespace-rules", s.writeTempFile("rules.yaml", testNamespaceRules)})
+	s.Equal(1, errorCode)
+}
@@ -25,10 +25,17 @@
 package cli
 
 import (
+	"time"
+
 	"github.com/urfave/cli/v2"
 )
 
 var (
+	namespaceRulesFlag = &cli.StringFlag{
+		Name:  FlagNamespaceRules,
+		Usage: "File with the rules Namespace settings must follow. Set it per environment with `tctl config set namespace-rules <file>`",
+	}
+
 	registerNamespaceFlags = []cli.Flag{
 		&cli.StringFlag{
 			Name:  FlagDescription,
@@ -74,6 +81,7 @@ var (
 			Name:  FlagVisibilityArchivalURI,
 			Usage: "Optionally specify visibility archival URI (cannot be changed after first time archival is enabled)",
 		},
+		namespaceRulesFlag,
 	}
 
 	updateNamespaceFlags = []cli.Flag{
@@ -125,6 +133,7 @@ var (
 			Name:  FlagPromoteNamespace,
 			Usage: "Promote local namespace to global namespace",
 		},
+		namespaceRulesFlag,
 	}
 
 	describeNamespaceFlags = []cli.Flag{
@@ -142,5 +151,71 @@ var (
 			Aliases: FlagYesAlias,
 			Usage:   "Confirm all prompts",
 		},
+		&cli.BoolFlag{
+			Name:  FlagWait,
+			Usage: "Wait until the server has removed the Namespace and its Workflows",
+		},
+		&cli.DurationFlag{
+			Name:  FlagWaitTimeout,
+			Usage: "How long to wait for the Namespace to be removed with --wait",
+			Value: 10 * time.Minute,
+		},
+	}
+
+	applyNamespacesFlags = []cli.Flag{
+		&cli.StringFlag{
+			Name:     FlagFile,
+			Aliases:  FlagFileAlias,
+			Usage:    "Namespace definition file",
+			Required: true,
+		},
+		&cli.BoolFlag{
+			Name:  FlagDryRun,
+			Usage: "Show what would change without applying it",
+		},
+		&cli.BoolFlag{
+			Name:    FlagYes,
+			Aliases: FlagYesAlias,
+			Usage:   "Confirm all prompts",
+		},
+		namespaceRulesFlag,
+	}
+
+	exportNamespacesFlags = []cli.Flag{
+		&cli.StringFlag{
+			Name:  FlagFormat,
+			Usage: "Definition format: yaml or json",
+			Value: definitionFormatYAML,
+		},
+		&cli.StringFlag{
+			Name:  FlagOutputFilename,
+			Usage: "Write the definition to a file instead of stdout",
+		},
+	}
+
+	failoverNamespaceFlags = []cli.Flag{
+		&cli.StringFlag{
+			Name:     FlagTo,
+			Usage:    "Cluster to make active",
+			Required: true,
+		},
+		&cli.StringFlag{
+			Name:  FlagBatch,
+			Usage: "Fail over every Namespace whose name matches this pattern, e.g. 'payments-*'",
+		},
+		&cli.DurationFlag{
+			Name:  FlagWaitTimeout,
+			Usage: "How long to wait for each Namespace to show the new failover version",
+			Value: time.Minute,
+		},
+		&cli.BoolFlag{
+			Name:    FlagYes,
+			Aliases: FlagYesAlias,
+			Usage:   "Confirm all prompts",
+		},
+	}
+
+	lintNamespacesFlags = []cli.Flag{
+		namespaceRulesFlag,
 	}
 )
@@ -0,0 +1,138 @@
+// The MIT License
+//
+// Copyright (c) 2022 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/urfave/cli/v2"
+	commonpb "go.temporal.io/api/common/v1"
+
+	"github.com/temporalio/tctl/cli/dataconverter"
+)
+
+const (
+	payloadMetadataEncoding    = "encoding"
+	payloadMetadataMessageType = "messageType"
+
+	payloadEncodingJSONPlain      = "json/plain"
+	payloadEncodingJSONProtobuf   = "json/protobuf"
+	payloadEncodingBinaryPlain    = "binary/plain"
+	payloadEncodingBinaryProtobuf = "binary/protobuf"
+)
+
+var flagsForPayloadInput = []cli.Flag{
+	&cli.StringFlag{
+		Name: FlagInputEncoding,
+		Usage: fmt.Sprintf("Encoding of the input: %s, %s, %s or %s. Input for binary encodings is sent byte for byte",
+			payloadEncodingJSONPlain, payloadEncodingJSONProtobuf, payloadEncodingBinaryPlain, payloadEncodingBinaryProtobuf),
+		Value: payloadEncodingJSONPlain,
+	},
+	&cli.StringSliceFlag{
+		Name: FlagInputMeta,
+		Usage: fmt.Sprintf("Metadata to set on the input payload in a format key=value, e.g. %s=my.package.MyMessage for protobuf encodings",
+			payloadMetadataMessageType),
+	},
+	&cli.BoolFlag{
+		Name:  FlagInputPayload,
+		Usage: "Treat the input as a fully formed Payload in JSON, with base64 encoded metadata values and data",
+	},
+}
+
+// isRawPayloadInput reports whether the input has to be sent as a payload built by the CLI,
+// rather than being decoded from JSON and encoded by the data converter
+func isRawPayloadInput(c *cli.Context) bool {
+	return c.Bool(FlagInputPayload) ||
+		c.IsSet(FlagInputMeta) ||
+		(c.IsSet(FlagInputEncoding) && c.String(FlagInputEncoding) != payloadEncodingJSONPlain)
+}
+
+// rawPayloadInputs builds a payload for every input according to --input-encoding, --input-meta and --input-payload.
+// The payloads still go through the codec of the configured data converter.
+func rawPayloadInputs(c *cli.Context, inputs [][]byte) ([]interface{}, error) {
+	if !dataconverter.AcceptsRawPayloads() {
+		return nil, fmt.Errorf("--%s, --%s and --%s can't be used with a data converter plugin, which has to encode the input itself",
+			FlagInputEncoding, FlagInputMeta, FlagInputPayload)
+	}
+	meta, err := SplitKeyValuePairs(c.StringSlice(FlagInputMeta))
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse input metadata: %w", err)
+	}
+	encoding := c.String(FlagInputEncoding)
+	if encoding == "" {
+		encoding = payloadEncodingJSONPlain
+	}
+
+	var result []interface{}
+	for _, input := range inputs {
+		if input == nil {
+			result = append(result, nil)
+			continue
+		}
+
+		payload, err := buildInputPayload(input, encoding, meta, c.Bool(FlagInputPayload))
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, dataconverter.RawPayload{Payload: payload})
+	}
+	return result, nil
+}
+
+func buildInputPayload(input []byte, encoding string, meta map[string]string, isPayload bool) (*commonpb.Payload, error) {
+	payload := &commonpb.Payload{}
+	if isPayload {
+		if err := jsonpb.Unmarshal(bytes.NewReader(input), payload); err != nil {
+			return nil, fmt.Errorf("input is not a valid Payload: %w", err)
+		}
+	} else {
+		if (encoding == payloadEncodingJSONPlain || encoding == payloadEncodingJSONProtobuf) && !json.Valid(input) {
+			return nil, fmt.Errorf("input is not valid JSON for encoding %s", encoding)
+		}
+		payload.Metadata = map[string][]byte{payloadMetadataEncoding: []byte(encoding)}
+		payload.Data = input
+	}
+
+	if payload.Metadata == nil {
+		payload.Metadata = make(map[string][]byte, len(meta))
+	}
+	for k, v := range meta {
+		payload.Metadata[k] = []byte(v)
+	}
+
+	switch string(payload.Metadata[payloadMetadataEncoding]) {
+	case "":
+		return nil, fmt.Errorf("input payload has no %s metadata", payloadMetadataEncoding)
+	case payloadEncodingJSONProtobuf, payloadEncodingBinaryProtobuf:
+		if len(payload.Metadata[payloadMetadataMessageType]) == 0 {
+			return nil, fmt.Errorf("protobuf input requires the message type, set it with --%s %s=<full message name>",
+				FlagInputMeta, payloadMetadataMessageType)
+		}
+	}
+	return payload, nil
+}
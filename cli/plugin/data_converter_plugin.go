@@ -23,6 +23,8 @@
 package plugin
 
 import (
+	"encoding/gob"
+	"encoding/json"
 	"fmt"
 	"net/rpc"
 
@@ -31,6 +33,12 @@ import (
 	"go.temporal.io/sdk/converter"
 )
 
+func init() {
+	// values decoded from JSON input are sent to the plugin as interface values
+	gob.Register(map[string]interface{}{})
+	gob.Register([]interface{}{})
+}
+
 type DataConverterRPC struct {
 	client *rpc.Client
 }
@@ -50,26 +58,38 @@ func NewDataConverterPlugin(name string) (converter.DataConverter, error) {
 }
 
 func (g *DataConverterRPC) FromPayload(payload *commonpb.Payload, valuePtr interface{}) error {
-	err := g.client.Call("Plugin.FromPayload", payload, valuePtr)
+	var data []byte
+	err := g.client.Call("Plugin.FromPayload", payload, &data)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	return json.Unmarshal(data, valuePtr)
 }
 
-func (g *DataConverterRPC) FromPayloads(payloads *commonpb.Payloads, valuePtr ...interface{}) error {
-	err := g.client.Call("Plugin.FromPayloads", payloads, valuePtr)
+func (g *DataConverterRPC) FromPayloads(payloads *commonpb.Payloads, valuePtrs ...interface{}) error {
+	var data [][]byte
+	err := g.client.Call("Plugin.FromPayloads", payloads, &data)
 	if err != nil {
 		return err
 	}
 
+	for i, valuePtr := range valuePtrs {
+		if i >= len(data) {
+			break
+		}
+		if err := json.Unmarshal(data[i], valuePtr); err != nil {
+			return fmt.Errorf("unable to decode payload %d: %w", i, err)
+		}
+	}
+
 	return nil
 }
 
 func (g *DataConverterRPC) ToPayload(value interface{}) (*commonpb.Payload, error) {
 	var payload commonpb.Payload
-	err := g.client.Call("Plugin.ToPayload", value, &payload)
+	// values are sent wrapped in a slice so that gob encodes them as interface values
+	err := g.client.Call("Plugin.ToPayload", []interface{}{value}, &payload)
 	if err != nil {
 		return nil, err
 	}
@@ -111,28 +131,66 @@ type DataConverterRPCServer struct {
 	Impl converter.DataConverter
 }
 
-func (s *DataConverterRPCServer) FromPayload(input *commonpb.Payload, resp *interface{}) error {
+// FromPayload decodes the payload and returns the value as JSON, since an arbitrary value pointer can't be
+// filled in across the plugin boundary
+func (s *DataConverterRPCServer) FromPayload(input *commonpb.Payload, resp *[]byte) error {
 	var result interface{}
-	err := s.Impl.FromPayload(input, result)
-	resp = &result
-	return err
+	if err := s.Impl.FromPayload(input, &result); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	*resp = data
+	return nil
 }
 
-func (s *DataConverterRPCServer) FromPayloads(input *commonpb.Payloads, resp *[]interface{}) error {
-	var results []interface{}
-	err := s.Impl.FromPayloads(input, results)
-	resp = &results
-	return err
+func (s *DataConverterRPCServer) FromPayloads(input *commonpb.Payloads, resp *[][]byte) error {
+	results := make([]interface{}, len(input.GetPayloads()))
+	valuePtrs := make([]interface{}, len(results))
+	for i := range results {
+		valuePtrs[i] = &results[i]
+	}
+	if err := s.Impl.FromPayloads(input, valuePtrs...); err != nil {
+		return err
+	}
+
+	data := make([][]byte, len(results))
+	for i, result := range results {
+		var err error
+		if data[i], err = json.Marshal(result); err != nil {
+			return err
+		}
+	}
+	*resp = data
+	return nil
 }
 
-func (s *DataConverterRPCServer) ToPayload(value interface{}, resp *commonpb.Payload) error {
-	resp, err := s.Impl.ToPayload(value)
-	return err
+func (s *DataConverterRPCServer) ToPayload(values []interface{}, resp *commonpb.Payload) error {
+	if len(values) != 1 {
+		return fmt.Errorf("expected a single value, got %d", len(values))
+	}
+	payload, err := s.Impl.ToPayload(values[0])
+	if err != nil {
+		return err
+	}
+	if payload != nil {
+		*resp = *payload
+	}
+	return nil
 }
 
 func (s *DataConverterRPCServer) ToPayloads(values []interface{}, resp *commonpb.Payloads) error {
-	resp, err := s.Impl.ToPayloads(values)
-	return err
+	payloads, err := s.Impl.ToPayloads(values...)
+	if err != nil {
+		return err
+	}
+	if payloads != nil {
+		*resp = *payloads
+	}
+	return nil
 }
 
 func (s *DataConverterRPCServer) ToString(input *commonpb.Payload, resp *string) error {
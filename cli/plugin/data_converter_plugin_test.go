@@ -0,0 +1,100 @@
+// The MIT License
+//
+// Copyright (c) 2022 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package plugin
+
+import (
+	"net"
+	"net/rpc"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"go.temporal.io/sdk/converter"
+)
+
+type (
+	DataConverterPluginSuite struct {
+		*require.Assertions
+		suite.Suite
+
+		client *DataConverterRPC
+	}
+)
+
+func TestDataConverterPluginSuite(t *testing.T) {
+	suite.Run(t, &DataConverterPluginSuite{})
+}
+
+func (s *DataConverterPluginSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+
+	server := rpc.NewServer()
+	s.NoError(server.RegisterName("Plugin", &DataConverterRPCServer{Impl: converter.GetDefaultDataConverter()}))
+	serverConn, clientConn := net.Pipe()
+	go server.ServeConn(serverConn)
+	s.client = &DataConverterRPC{client: rpc.NewClient(clientConn)}
+}
+
+func (s *DataConverterPluginSuite) TearDownTest() {
+	s.NoError(s.client.client.Close())
+}
+
+func (s *DataConverterPluginSuite) TestToPayloads() {
+	values := []interface{}{"text", map[string]interface{}{"amount": 10.5, "items": []interface{}{"a", "b"}}, nil}
+	expected, err := converter.GetDefaultDataConverter().ToPayloads(values...)
+	s.NoError(err)
+
+	payloads, err := s.client.ToPayloads(values...)
+	s.NoError(err)
+	s.Equal(expected, payloads)
+}
+
+func (s *DataConverterPluginSuite) TestToPayload() {
+	expected, err := converter.GetDefaultDataConverter().ToPayload(map[string]interface{}{"approved": true})
+	s.NoError(err)
+
+	payload, err := s.client.ToPayload(map[string]interface{}{"approved": true})
+	s.NoError(err)
+	s.Equal(expected, payload)
+}
+
+func (s *DataConverterPluginSuite) TestFromPayloads() {
+	type order struct {
+		ID     string
+		Amount float64
+	}
+	payloads, err := converter.GetDefaultDataConverter().ToPayloads(order{ID: "o-1", Amount: 3}, "text")
+	s.NoError(err)
+
+	var o order
+	var text string
+	s.NoError(s.client.FromPayloads(payloads, &o, &text))
+	s.Equal(order{ID: "o-1", Amount: 3}, o)
+	s.Equal("text", text)
+
+	var single order
+	s.NoError(s.client.FromPayload(payloads.Payloads[0], &single))
+	s.Equal(o, single)
+}
@@ -35,25 +35,46 @@ const (
 	HeadersProviderPluginType = "HeadersProvider"
 )
 
+const (
+	// HeadersProviderProtocolVersion is the protocol version of HeadersProvider plugins
+	HeadersProviderProtocolVersion = 1
+	// DataConverterProtocolVersion is the protocol version of DataConverter plugins. Version 2 changed
+	// the DataConverter RPC signatures, so DataConverter plugins built for version 1 are not dispensed.
+	DataConverterProtocolVersion = 2
+)
+
 var (
+	// PluginHandshakeConfig is the handshake HeadersProvider plugins serve with
 	PluginHandshakeConfig = plugin.HandshakeConfig{
-		ProtocolVersion:  1,
+		ProtocolVersion:  HeadersProviderProtocolVersion,
 		MagicCookieKey:   "TEMPORAL_CLI_PLUGIN",
 		MagicCookieValue: "abb3e448baf947eba1847b10a38554db",
 	}
 
-	pluginMap = map[string]plugin.Plugin{
-		DataConverterPluginType:   &DataConverterPlugin{},
-		HeadersProviderPluginType: &HeadersProviderPlugin{},
+	// DataConverterPluginHandshakeConfig is the handshake DataConverter plugins serve with
+	DataConverterPluginHandshakeConfig = plugin.HandshakeConfig{
+		ProtocolVersion:  DataConverterProtocolVersion,
+		MagicCookieKey:   PluginHandshakeConfig.MagicCookieKey,
+		MagicCookieValue: PluginHandshakeConfig.MagicCookieValue,
+	}
+
+	versionedPluginMap = map[int]plugin.PluginSet{
+		HeadersProviderProtocolVersion: {
+			HeadersProviderPluginType: &HeadersProviderPlugin{},
+		},
+		DataConverterProtocolVersion: {
+			DataConverterPluginType:   &DataConverterPlugin{},
+			HeadersProviderPluginType: &HeadersProviderPlugin{},
+		},
 	}
 )
 
 func newPluginClient(kind string, name string) (interface{}, error) {
 	pluginClient := plugin.NewClient(&plugin.ClientConfig{
-		HandshakeConfig: PluginHandshakeConfig,
-		Plugins:         pluginMap,
-		Cmd:             exec.Command(name),
-		Managed:         true,
+		HandshakeConfig:  plugin.HandshakeConfig{MagicCookieKey: PluginHandshakeConfig.MagicCookieKey, MagicCookieValue: PluginHandshakeConfig.MagicCookieValue},
+		VersionedPlugins: versionedPluginMap,
+		Cmd:              exec.Command(name),
+		Managed:          true,
 		Logger: hclog.New(&hclog.LoggerOptions{
 			Name:  "tctl",
 			Level: hclog.LevelFromString("INFO"),
@@ -64,6 +85,9 @@ func newPluginClient(kind string, name string) (interface{}, error) {
 	if err != nil {
 		return nil, fmt.Errorf("unable to create plugin client: %w", err)
 	}
+	if _, ok := versionedPluginMap[pluginClient.NegotiatedVersion()][kind]; !ok {
+		return nil, fmt.Errorf("plugin %s uses protocol version %d, which does not support %s plugins", name, pluginClient.NegotiatedVersion(), kind)
+	}
 
 	return rpcClient.Dispense(kind)
 }
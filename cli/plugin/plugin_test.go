@@ -0,0 +1,101 @@
+// The MIT License
+//
+// Copyright (c) 2022 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package plugin
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/go-plugin"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/converter"
+)
+
+// testPluginEnv makes the test binary serve a plugin instead of running the tests
+const testPluginEnv = "TCTL_TEST_PLUGIN"
+
+type testHeadersProvider struct{}
+
+func (testHeadersProvider) GetHeaders(context.Context) (map[string]string, error) {
+	return map[string]string{"Authorization": "token"}, nil
+}
+
+func TestMain(m *testing.M) {
+	switch os.Getenv(testPluginEnv) {
+	case "headers-provider-v1":
+		// served the way HeadersProvider plugins built against protocol version 1 do
+		plugin.Serve(&plugin.ServeConfig{
+			HandshakeConfig: plugin.HandshakeConfig{
+				ProtocolVersion:  1,
+				MagicCookieKey:   PluginHandshakeConfig.MagicCookieKey,
+				MagicCookieValue: PluginHandshakeConfig.MagicCookieValue,
+			},
+			Plugins: map[string]plugin.Plugin{HeadersProviderPluginType: &HeadersProviderPlugin{Impl: testHeadersProvider{}}},
+		})
+		os.Exit(0)
+	case "data-converter-v1":
+		plugin.Serve(&plugin.ServeConfig{
+			HandshakeConfig: PluginHandshakeConfig,
+			Plugins:         map[string]plugin.Plugin{DataConverterPluginType: &DataConverterPlugin{Impl: converter.GetDefaultDataConverter()}},
+		})
+		os.Exit(0)
+	case "data-converter-v2":
+		plugin.Serve(&plugin.ServeConfig{
+			HandshakeConfig: DataConverterPluginHandshakeConfig,
+			Plugins:         map[string]plugin.Plugin{DataConverterPluginType: &DataConverterPlugin{Impl: converter.GetDefaultDataConverter()}},
+		})
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+func TestHeadersProviderPlugin_ProtocolVersion1(t *testing.T) {
+	t.Setenv(testPluginEnv, "headers-provider-v1")
+	defer StopPlugins()
+
+	provider, err := NewHeadersProviderPlugin(os.Args[0])
+	require.NoError(t, err)
+	headers, err := provider.GetHeaders(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"Authorization": "token"}, headers)
+}
+
+func TestDataConverterPlugin_ProtocolVersion(t *testing.T) {
+	defer StopPlugins()
+
+	t.Setenv(testPluginEnv, "data-converter-v1")
+	_, err := NewDataConverterPlugin(os.Args[0])
+	require.ErrorContains(t, err, "does not support DataConverter plugins")
+
+	t.Setenv(testPluginEnv, "data-converter-v2")
+	dataConverter, err := NewDataConverterPlugin(os.Args[0])
+	require.NoError(t, err)
+	payload, err := dataConverter.ToPayload("text")
+	require.NoError(t, err)
+	var value string
+	require.NoError(t, dataConverter.FromPayload(payload, &value))
+	require.Equal(t, "text", value)
+}
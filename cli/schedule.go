@@ -25,6 +25,8 @@
 package cli
 
 import (
+	"time"
+
 	"github.com/urfave/cli/v2"
 	"golang.org/x/exp/slices"
 
@@ -38,6 +40,47 @@ func newScheduleCommands() []*cli.Command {
 		Usage:    "Schedule Id",
 		Required: true,
 	}
+	// commands that can act on many schedules take --all or filters instead of --schedule-id
+	bulkSid := &cli.StringFlag{
+		Name:    FlagScheduleID,
+		Aliases: FlagScheduleIDAlias,
+		Usage:   "Schedule Id",
+	}
+	scheduleFilterFlags := []cli.Flag{
+		&cli.StringFlag{
+			Name:  FlagScheduleIDPrefix,
+			Usage: "Only schedules with Ids starting with this prefix",
+		},
+		&cli.StringFlag{
+			Name:  FlagWorkflowType,
+			Usage: "Only schedules starting workflows of this type",
+		},
+		&cli.BoolFlag{
+			Name:  FlagPaused,
+			Usage: "Only paused schedules, or unpaused schedules with --paused=false",
+		},
+	}
+	scheduleBulkFlags := append([]cli.Flag{
+		&cli.BoolFlag{
+			Name:  FlagAll,
+			Usage: "Act on all schedules of the namespace, or all schedules matching the filters",
+		},
+		&cli.BoolFlag{
+			Name:    FlagYes,
+			Aliases: FlagYesAlias,
+			Usage:   "Confirm the number of schedules without prompting",
+		},
+		&cli.IntFlag{
+			Name:  FlagConcurrency,
+			Usage: "Number of schedules changed at once",
+			Value: 10,
+		},
+		&cli.Float64Flag{
+			Name:  FlagRPS,
+			Usage: "Maximum requests per second, unlimited if not set",
+		},
+	}, scheduleFilterFlags...)
+
 	overlap := &cli.StringFlag{
 		Name:  FlagOverlapPolicy,
 		Usage: "Overlap policy: Skip, BufferOne, BufferAll, CancelOther, TerminateOther, AllowAll",
@@ -128,6 +171,33 @@ func newScheduleCommands() []*cli.Command {
 		FlagSearchAttribute,
 	)...)
 
+	// with --patch only the given fields change, so update can't require the workflow options
+	updateFlags := []cli.Flag{
+		sid,
+		&cli.BoolFlag{
+			Name:  FlagPatch,
+			Usage: "Change only the fields given by flags and keep the rest of the current schedule. Calendars, cron strings and intervals are added to the spec",
+		},
+		&cli.StringSliceFlag{
+			Name:  FlagRemoveCalendar,
+			Usage: `Calendar specification in JSON to remove from the spec with --patch, e.g. {"dayOfWeek":"Fri","hour":"17","minute":"5"}`,
+		},
+		&cli.BoolFlag{
+			Name:  FlagDryRun,
+			Usage: "Show the changes made by --patch without applying them",
+		},
+	}
+	for _, f := range createFlags[1:] {
+		switch f.Names()[0] {
+		case FlagTaskQueue:
+			updateFlags = append(updateFlags, &cli.StringFlag{Name: FlagTaskQueue, Aliases: FlagTaskQueueAlias, Usage: "Task queue"})
+		case FlagWorkflowType:
+			updateFlags = append(updateFlags, &cli.StringFlag{Name: FlagWorkflowType, Usage: "Workflow type name"})
+		default:
+			updateFlags = append(updateFlags, f)
+		}
+	}
+
 	return []*cli.Command{
 		{
 			Name:        "create",
@@ -138,16 +208,104 @@ func newScheduleCommands() []*cli.Command {
 		},
 		{
 			Name:        "update",
-			Usage:       "Updates a schedule with a new definition (full replacement, not patch)",
-			Description: "Takes a schedule specification plus all the same args as starting a workflow",
-			Flags:       createFlags,
+			Usage:       "Updates a schedule with a new definition (full replacement, or patch with --patch)",
+			Description: "Takes a schedule specification plus all the same args as starting a workflow. Without --patch, every part of the schedule that is not given is reset. With --patch, the current schedule is fetched, only the given fields are changed, the changes are shown and the update is rejected if the schedule was changed concurrently",
+			Flags:       updateFlags,
 			Action:      UpdateSchedule,
 		},
 		{
-			Name:  "toggle",
-			Usage: "Pauses or unpauses a schedule",
+			Name:        "apply",
+			Usage:       "Creates or updates a schedule from a YAML or JSON definition",
+			Description: "The schedule is created if it does not exist, and updated only when its spec, action, policies or state differ from the file. Use `tctl schedule export` to get the definition of an existing schedule",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     FlagFile,
+					Aliases:  FlagFileAlias,
+					Usage:    "Schedule definition file",
+					Required: true,
+				},
+				&cli.BoolFlag{
+					Name:  FlagDryRun,
+					Usage: "Show what would change without applying it",
+				},
+			},
+			Action: ApplySchedule,
+		},
+		{
+			Name:  "export",
+			Usage: "Writes the definition of a schedule in the format read by apply",
 			Flags: []cli.Flag{
 				sid,
+				&cli.StringFlag{
+					Name:  FlagFormat,
+					Usage: "Definition format: yaml or json",
+					Value: definitionFormatYAML,
+				},
+				&cli.StringFlag{
+					Name:  FlagOutputFilename,
+					Usage: "Write the definition to a file instead of stdout",
+				},
+			},
+			Action: ExportSchedule,
+		},
+		{
+			Name:        "preview",
+			Usage:       "Shows the next action times of a schedule spec without creating a schedule",
+			Description: "Takes the same schedule specification as create, or the Id of an existing schedule. Times are computed locally following the server's rules for calendars, cron strings, intervals, time zones and jitter",
+			Flags: append(append([]cli.Flag{
+				&cli.StringFlag{
+					Name:    FlagScheduleID,
+					Aliases: FlagScheduleIDAlias,
+					Usage:   "Preview an existing schedule instead of the specification flags",
+				},
+				&cli.IntFlag{
+					Name:  FlagCount,
+					Usage: "Number of action times to show",
+					Value: 10,
+				},
+			}, scheduleSpecFlags...), flags.FlagsForRendering...),
+			Action: PreviewSchedule,
+		},
+		{
+			Name:        "migrate-cron",
+			Usage:       "Creates schedules for running cron workflows",
+			Description: "Every running cron workflow matching the query gets a schedule with the workflow Id as schedule Id, the same cron string and the workflow type, task queue, input, memo and search attributes of its start event",
+			Flags: append([]cli.Flag{
+				&cli.StringFlag{
+					Name:    FlagQuery,
+					Aliases: FlagQueryAlias,
+					Usage:   "Visibility query of the cron workflows to migrate, all running workflows if not set",
+				},
+				&cli.BoolFlag{
+					Name:  FlagTerminateOld,
+					Usage: "Terminate each cron workflow once its schedule is created and confirmed",
+				},
+				&cli.BoolFlag{
+					Name:  FlagDryRun,
+					Usage: "Report the schedules that would be created without creating them",
+				},
+				&cli.BoolFlag{
+					Name:    FlagYes,
+					Aliases: FlagYesAlias,
+					Usage:   "Confirm the number of schedules without prompting",
+				},
+				&cli.IntFlag{
+					Name:  FlagConcurrency,
+					Usage: "Number of workflows migrated at once",
+					Value: 10,
+				},
+				&cli.Float64Flag{
+					Name:  FlagRPS,
+					Usage: "Maximum requests per second, unlimited if not set",
+				},
+			}, flags.FlagsForRendering...),
+			Action: MigrateCronWorkflows,
+		},
+		{
+			Name:  "toggle",
+			Usage: "Pauses or unpauses a schedule, or all schedules matching --all or filters",
+			Flags: append([]cli.Flag{
+				bulkSid,
 				&cli.BoolFlag{
 					Name:  FlagPause,
 					Usage: "Pauses the schedule",
@@ -161,16 +319,16 @@ func newScheduleCommands() []*cli.Command {
 					Usage: "Free-form text to describe reason for pause/unpause",
 					Value: "(no reason provided)",
 				},
-			},
+			}, scheduleBulkFlags...),
 			Action: ToggleSchedule,
 		},
 		{
 			Name:  "trigger",
-			Usage: "Triggers an immediate action",
-			Flags: []cli.Flag{
-				sid,
+			Usage: "Triggers an immediate action, on one schedule or all schedules matching --all or filters",
+			Flags: append([]cli.Flag{
+				bulkSid,
 				overlap,
-			},
+			}, scheduleBulkFlags...),
 			Action: TriggerSchedule,
 		},
 		{
@@ -189,12 +347,29 @@ func newScheduleCommands() []*cli.Command {
 					Usage:    "Backfill end time",
 					Required: true,
 				},
+				&cli.BoolFlag{
+					Name:  FlagDryRun,
+					Usage: "List the action times in the range without backfilling",
+				},
+				&cli.StringFlag{
+					Name:  FlagChunk,
+					Usage: "Split the range into sequential backfill requests of this duration, e.g. 1d",
+				},
+				&cli.BoolFlag{
+					Name:  FlagWait,
+					Usage: "Wait for the workflows of each backfill request to finish before sending the next one",
+				},
+				&cli.DurationFlag{
+					Name:  FlagWaitTimeout,
+					Usage: "How long to wait for the workflows of each backfill request with --wait",
+					Value: time.Hour,
+				},
 			},
 			Action: BackfillSchedule,
 		},
 		{
 			Name:  "describe",
-			Usage: "Get schedule configuration and current state",
+			Usage: "Get schedule configuration and current state, with upcoming run times and recent actions",
 			Flags: append([]cli.Flag{
 				sid,
 				&cli.BoolFlag{
@@ -206,16 +381,16 @@ func newScheduleCommands() []*cli.Command {
 		},
 		{
 			Name:  "delete",
-			Usage: "Deletes a schedule",
-			Flags: []cli.Flag{
-				sid,
-			},
+			Usage: "Deletes a schedule, or all schedules matching --all or filters",
+			Flags: append([]cli.Flag{
+				bulkSid,
+			}, scheduleBulkFlags...),
 			Action: DeleteSchedule,
 		},
 		{
 			Name:   "list",
 			Usage:  "Lists schedules",
-			Flags:  append([]cli.Flag{}, flags.FlagsForPaginationAndRendering...),
+			Flags:  append(append([]cli.Flag{}, scheduleFilterFlags...), flags.FlagsForPaginationAndRendering...),
 			Action: ListSchedules,
 		},
 	}
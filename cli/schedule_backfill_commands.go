@@ -0,0 +1,264 @@
+// The MIT License
+//
+// Copyright (c) 2022 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pborman/uuid"
+	"github.com/temporalio/tctl-kit/pkg/color"
+	"github.com/temporalio/tctl-kit/pkg/output"
+	"github.com/urfave/cli/v2"
+	enumspb "go.temporal.io/api/enums/v1"
+	schedpb "go.temporal.io/api/schedule/v1"
+	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/server/common/primitives/timestamp"
+)
+
+// backfillPollInterval is how often the schedule is described while waiting for a backfill chunk to finish
+var backfillPollInterval = 5 * time.Second
+
+var backfillOverlapDescriptions = map[enumspb.ScheduleOverlapPolicy]string{
+	enumspb.SCHEDULE_OVERLAP_POLICY_SKIP:            "actions are skipped while a workflow of the schedule is running, so as few as one workflow may start",
+	enumspb.SCHEDULE_OVERLAP_POLICY_BUFFER_ONE:      "one action is buffered while a workflow of the schedule is running, the others are skipped",
+	enumspb.SCHEDULE_OVERLAP_POLICY_BUFFER_ALL:      "all actions are buffered and their workflows run one after another",
+	enumspb.SCHEDULE_OVERLAP_POLICY_CANCEL_OTHER:    "every action cancels the running workflow, so only the last one runs to completion",
+	enumspb.SCHEDULE_OVERLAP_POLICY_TERMINATE_OTHER: "every action terminates the running workflow, so only the last one runs to completion",
+	enumspb.SCHEDULE_OVERLAP_POLICY_ALLOW_ALL:       "all workflows start right away and run concurrently",
+}
+
+// backfillChunk is a part of the backfill time range. Like the server, it excludes its start time and includes its end time.
+type backfillChunk struct {
+	start, end time.Time
+	times      []*schedulePreviewTime
+}
+
+// backfillScheduleInChunks backfills the time range with one request per --chunk, optionally waiting for the
+// workflows of a chunk to finish before sending the next one. With --dry-run it only lists the action times.
+func backfillScheduleInChunks(c *cli.Context, startTime time.Time, endTime time.Time, overlap enumspb.ScheduleOverlapPolicy) error {
+	frontendClient, namespace, scheduleID, err := scheduleBaseArgs(c)
+	if err != nil {
+		return err
+	}
+	var chunkSize time.Duration
+	if c.IsSet(FlagChunk) {
+		if chunkSize, err = timestamp.ParseDuration(c.String(FlagChunk)); err != nil {
+			return fmt.Errorf("invalid chunk duration: %w", err)
+		}
+		if chunkSize <= 0 {
+			return fmt.Errorf("chunk duration must be positive")
+		}
+	}
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+	resp, err := frontendClient.DescribeSchedule(ctx, &workflowservice.DescribeScheduleRequest{
+		Namespace:  namespace,
+		ScheduleId: scheduleID,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to describe schedule: %w", err)
+	}
+	preview, err := newPreviewSpec(resp.GetSchedule().GetSpec())
+	if err != nil {
+		return err
+	}
+	chunks := splitBackfill(preview, startTime, endTime, chunkSize)
+
+	if c.Bool(FlagDryRun) {
+		if overlap == enumspb.SCHEDULE_OVERLAP_POLICY_UNSPECIFIED {
+			overlap = resp.GetSchedule().GetPolicies().GetOverlapPolicy()
+		}
+		return printBackfillPreview(c, chunks, overlap)
+	}
+
+	for i, chunk := range chunks {
+		var before *schedpb.ScheduleInfo
+		if c.Bool(FlagWait) {
+			if before, err = describeScheduleProgress(c, frontendClient, namespace, scheduleID); err != nil {
+				return err
+			}
+		}
+		ctx, cancel := newContext(c)
+		_, err = frontendClient.PatchSchedule(ctx, &workflowservice.PatchScheduleRequest{
+			Namespace:  namespace,
+			ScheduleId: scheduleID,
+			Patch: &schedpb.SchedulePatch{
+				BackfillRequest: []*schedpb.BackfillRequest{
+					{
+						StartTime:     timestamp.TimePtr(chunk.start),
+						EndTime:       timestamp.TimePtr(chunk.end),
+						OverlapPolicy: overlap,
+					},
+				},
+			},
+			Identity:  getCliIdentity(),
+			RequestId: uuid.New(),
+		})
+		cancel()
+		if err != nil {
+			return fmt.Errorf("unable to backfill schedule from %s to %s: %w", formatBackfillTime(chunk.start), formatBackfillTime(chunk.end), err)
+		}
+		fmt.Printf("Chunk %d of %d: backfill request sent for %s to %s, %d actions\n",
+			i+1, len(chunks), formatBackfillTime(chunk.start), formatBackfillTime(chunk.end), len(chunk.times))
+
+		if c.Bool(FlagWait) && len(chunk.times) > 0 {
+			if err := waitForBackfillChunk(c, frontendClient, namespace, scheduleID, before, chunk); err != nil {
+				return err
+			}
+			fmt.Printf("Chunk %d of %d: workflows finished\n", i+1, len(chunks))
+		}
+	}
+
+	fmt.Println(color.Green(c, "Backfill requests sent"))
+	return nil
+}
+
+// splitBackfill splits the time range into chunks of the given size, or a single chunk if size is 0,
+// and computes the action times of each chunk
+func splitBackfill(preview *previewSpec, startTime time.Time, endTime time.Time, size time.Duration) []*backfillChunk {
+	var chunks []*backfillChunk
+	for start := startTime; start.Before(endTime) || len(chunks) == 0; {
+		end := endTime
+		if size > 0 && start.Add(size).Before(endTime) {
+			end = start.Add(size)
+		}
+		chunks = append(chunks, &backfillChunk{start: start, end: end, times: preview.timesBetween(start, end)})
+		start = end
+	}
+	return chunks
+}
+
+// timesBetween returns the action times after start and up to end, following the server: an action
+// is part of the range if its jittered time is, and the next action is looked up after the jittered time
+func (ps *previewSpec) timesBetween(start time.Time, end time.Time) []*schedulePreviewTime {
+	var times []*schedulePreviewTime
+	for after := start; ; {
+		nominal, maxJitter := ps.nextTime(after)
+		if nominal.IsZero() {
+			return times
+		}
+		actionTime := addScheduleJitter(nominal, maxJitter)
+		if actionTime.After(end) {
+			return times
+		}
+		times = append(times, &schedulePreviewTime{
+			NominalTime:  formatPreviewTime(nominal, ps.tz),
+			ActionTime:   formatPreviewTime(actionTime, ps.tz),
+			JitterWindow: formatPreviewTime(nominal.Add(maxJitter), ps.tz),
+			UTC:          nominal,
+		})
+		after = actionTime
+	}
+}
+
+func printBackfillPreview(c *cli.Context, chunks []*backfillChunk, overlap enumspb.ScheduleOverlapPolicy) error {
+	var items []interface{}
+	for i, chunk := range chunks {
+		for _, t := range chunk.times {
+			items = append(items, struct {
+				Chunk       int
+				NominalTime string
+				ActionTime  string
+				UTC         time.Time
+			}{i + 1, t.NominalTime, t.ActionTime, t.UTC})
+		}
+	}
+	if len(items) == 0 {
+		fmt.Println(color.Yellow(c, "The schedule takes no actions in this time range"))
+		return nil
+	}
+	opts := &output.PrintOptions{
+		Fields:     []string{"Chunk", "NominalTime", "ActionTime"},
+		FieldsLong: []string{"UTC"},
+	}
+	if len(chunks) == 1 {
+		opts.Fields = opts.Fields[1:]
+	}
+	if err := output.PrintItems(c, items, opts); err != nil {
+		return err
+	}
+
+	if overlap == enumspb.SCHEDULE_OVERLAP_POLICY_UNSPECIFIED {
+		overlap = enumspb.SCHEDULE_OVERLAP_POLICY_SKIP
+	}
+	fmt.Printf("%d actions in %d requests. Overlap policy %s: %s\n", len(items), len(chunks), overlap, backfillOverlapDescriptions[overlap])
+	return nil
+}
+
+func formatBackfillTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+// describeScheduleProgress returns the schedule info, which counts the actions taken or skipped and lists the running workflows
+func describeScheduleProgress(c *cli.Context, frontendClient workflowservice.WorkflowServiceClient, namespace string, scheduleID string) (*schedpb.ScheduleInfo, error) {
+	ctx, cancel := newContext(c)
+	defer cancel()
+	resp, err := frontendClient.DescribeSchedule(ctx, &workflowservice.DescribeScheduleRequest{
+		Namespace:  namespace,
+		ScheduleId: scheduleID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to describe schedule: %w", err)
+	}
+	return resp.GetInfo(), nil
+}
+
+// waitForBackfillChunk waits until the schedule has taken or skipped the actions of a chunk and none of its workflows are running.
+// Actions scheduled after the chunk are regular actions of the schedule and are not counted, as far as the recent actions show them.
+func waitForBackfillChunk(c *cli.Context, frontendClient workflowservice.WorkflowServiceClient, namespace string, scheduleID string, before *schedpb.ScheduleInfo, chunk *backfillChunk) error {
+	var lastBefore time.Time
+	for _, action := range before.GetRecentActions() {
+		if t := timestamp.TimeValue(action.GetActualTime()); t.After(lastBefore) {
+			lastBefore = t
+		}
+	}
+
+	expected := int64(len(chunk.times))
+	timeout := c.Duration(FlagWaitTimeout)
+	deadline := time.Now().Add(timeout)
+	for {
+		info, err := describeScheduleProgress(c, frontendClient, namespace, scheduleID)
+		if err != nil {
+			return err
+		}
+		done := info.GetActionCount() + info.GetOverlapSkipped() - before.GetActionCount() - before.GetOverlapSkipped()
+		for _, action := range info.GetRecentActions() {
+			if timestamp.TimeValue(action.GetActualTime()).After(lastBefore) && timestamp.TimeValue(action.GetScheduleTime()).After(chunk.end) {
+				done--
+			}
+		}
+		if done >= expected && len(info.GetRunningWorkflows()) == 0 {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("backfill of %s to %s didn't finish within %v: %d of %d actions taken or skipped, %d workflows running. "+
+				"Actions can be dropped by the server if the schedule changed or its buffer was full, check them with `tctl schedule describe`",
+				formatBackfillTime(chunk.start), formatBackfillTime(chunk.end), timeout, done, expected, len(info.GetRunningWorkflows()))
+		}
+		time.Sleep(backfillPollInterval)
+	}
+}
@@ -0,0 +1,234 @@
+// The MIT License
+//
+// Copyright (c) 2022 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/pborman/uuid"
+	"github.com/temporalio/tctl-kit/pkg/color"
+	"github.com/temporalio/tctl-kit/pkg/output"
+	"github.com/urfave/cli/v2"
+	schedpb "go.temporal.io/api/schedule/v1"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/server/common/backoff"
+)
+
+const (
+	scheduleBulkRetryInitialInterval = 200 * time.Millisecond
+	scheduleBulkRetryMaxAttempts     = 5
+)
+
+// scheduleFilter selects schedules by the --schedule-id-prefix, --workflow-type and --paused options
+type scheduleFilter struct {
+	idPrefix     string
+	workflowType string
+	paused       *bool
+}
+
+func newScheduleFilter(c *cli.Context) *scheduleFilter {
+	filter := &scheduleFilter{
+		idPrefix:     c.String(FlagScheduleIDPrefix),
+		workflowType: c.String(FlagWorkflowType),
+	}
+	if c.IsSet(FlagPaused) {
+		paused := c.Bool(FlagPaused)
+		filter.paused = &paused
+	}
+	return filter
+}
+
+// isSet reports whether the filter selects anything other than all schedules
+func (f *scheduleFilter) isSet() bool {
+	return f.idPrefix != "" || f.workflowType != "" || f.paused != nil
+}
+
+func (f *scheduleFilter) matches(entry *schedpb.ScheduleListEntry) (bool, error) {
+	if !strings.HasPrefix(entry.GetScheduleId(), f.idPrefix) {
+		return false, nil
+	}
+	if f.workflowType == "" && f.paused == nil {
+		return true, nil
+	}
+	info := entry.GetInfo()
+	if info == nil {
+		return false, fmt.Errorf("unable to filter schedules: extended schedule information is not available without Elasticsearch")
+	}
+	if f.workflowType != "" && info.GetWorkflowType().GetName() != f.workflowType {
+		return false, nil
+	}
+	if f.paused != nil && info.GetPaused() != *f.paused {
+		return false, nil
+	}
+	return true, nil
+}
+
+// isScheduleBulkOperation reports whether a schedule command has to act on the schedules selected by --all
+// or the filter options rather than on --schedule-id
+func isScheduleBulkOperation(c *cli.Context) (bool, error) {
+	bulk := c.Bool(FlagAll) || newScheduleFilter(c).isSet()
+	if bulk && c.IsSet(FlagScheduleID) {
+		return false, fmt.Errorf("option --%s can't be combined with --%s or schedule filters", FlagScheduleID, FlagAll)
+	}
+	if !bulk && !c.IsSet(FlagScheduleID) {
+		return false, fmt.Errorf("option --%s, --%s or a schedule filter is required", FlagScheduleID, FlagAll)
+	}
+	return bulk, nil
+}
+
+// listFilteredScheduleIDs pages through all schedules of the namespace and returns the Ids of those matching the filter
+func listFilteredScheduleIDs(c *cli.Context, frontendClient workflowservice.WorkflowServiceClient, namespace string, filter *scheduleFilter) ([]string, error) {
+	var ids []string
+	var npt []byte
+	for {
+		ctx, cancel := newContext(c)
+		resp, err := frontendClient.ListSchedules(ctx, &workflowservice.ListSchedulesRequest{
+			Namespace:     namespace,
+			NextPageToken: npt,
+		})
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("unable to list schedules: %w", err)
+		}
+		for _, entry := range resp.GetSchedules() {
+			ok, err := filter.matches(entry)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				ids = append(ids, entry.GetScheduleId())
+			}
+		}
+		npt = resp.GetNextPageToken()
+		if len(npt) == 0 {
+			return ids, nil
+		}
+	}
+}
+
+// scheduleBulkOperation acts on a single schedule. The request Id stays the same when the operation is retried.
+type scheduleBulkOperation func(ctx context.Context, frontendClient workflowservice.WorkflowServiceClient, namespace string, scheduleID string, requestID string) error
+
+type scheduleBulkResult struct {
+	ScheduleId string
+	Status     string
+	Error      string
+}
+
+// runScheduleBulkOperation applies op to every schedule selected by --all or the filter options,
+// after confirming the number of schedules, and prints the result of each one
+func runScheduleBulkOperation(c *cli.Context, verb string, op scheduleBulkOperation) error {
+	frontendClient := cFactory.FrontendClient(c)
+	namespace, err := requiredFlag(c, FlagNamespace)
+	if err != nil {
+		return err
+	}
+
+	ids, err := listFilteredScheduleIDs(c, frontendClient, namespace, newScheduleFilter(c))
+	if err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		fmt.Println(color.Yellow(c, "No schedules match"))
+		return nil
+	}
+
+	promptMsg := fmt.Sprintf(
+		"Will %s %v schedules. Continue? Y/N",
+		verb,
+		color.Yellow(c, "%v", len(ids)),
+	)
+	if !promptYes(promptMsg, c.Bool(FlagYes)) {
+		return nil
+	}
+
+	retryPolicy := backoff.NewExponentialRetryPolicy(scheduleBulkRetryInitialInterval).
+		WithMaximumAttempts(scheduleBulkRetryMaxAttempts)
+	results := make([]scheduleBulkResult, len(ids))
+	var failed int32
+	runConcurrently(len(ids), c.Int(FlagConcurrency), c.Float64(FlagRPS), func(i int) {
+		result := scheduleBulkResult{ScheduleId: ids[i], Status: "OK"}
+		requestID := uuid.New()
+		err := backoff.ThrottleRetry(func() error {
+			ctx, cancel := newContext(c)
+			defer cancel()
+			return op(ctx, frontendClient, namespace, ids[i], requestID)
+		}, retryPolicy, isTransientError)
+		if err != nil {
+			atomic.AddInt32(&failed, 1)
+			result.Status = "FAILED"
+			result.Error = err.Error()
+		}
+		results[i] = result
+	})
+
+	items := make([]interface{}, len(results))
+	for i, r := range results {
+		items[i] = r
+	}
+	if err := output.PrintItems(c, items, &output.PrintOptions{Fields: []string{"ScheduleId", "Status", "Error"}}); err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("unable to %s %d of %d schedules", verb, failed, len(ids))
+	}
+	fmt.Println(color.Green(c, "Completed %s for %d schedules", verb, len(ids)))
+	return nil
+}
+
+// patchSchedules sends the same patch to every selected schedule
+func patchSchedules(c *cli.Context, verb string, patch *schedpb.SchedulePatch) error {
+	return runScheduleBulkOperation(c, verb, func(ctx context.Context, frontendClient workflowservice.WorkflowServiceClient, namespace string, scheduleID string, requestID string) error {
+		_, err := frontendClient.PatchSchedule(ctx, &workflowservice.PatchScheduleRequest{
+			Namespace:  namespace,
+			ScheduleId: scheduleID,
+			Patch:      patch,
+			Identity:   getCliIdentity(),
+			RequestId:  requestID,
+		})
+		return err
+	})
+}
+
+func deleteSchedules(c *cli.Context) error {
+	return runScheduleBulkOperation(c, "delete", func(ctx context.Context, frontendClient workflowservice.WorkflowServiceClient, namespace string, scheduleID string, _ string) error {
+		_, err := frontendClient.DeleteSchedule(ctx, &workflowservice.DeleteScheduleRequest{
+			Namespace:  namespace,
+			ScheduleId: scheduleID,
+			Identity:   getCliIdentity(),
+		})
+		if _, ok := err.(*serviceerror.NotFound); ok {
+			// already deleted, e.g. by an earlier attempt
+			return nil
+		}
+		return err
+	})
+}
@@ -25,6 +25,7 @@
 package cli
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strings"
@@ -273,10 +274,22 @@ func CreateSchedule(c *cli.Context) error {
 }
 
 func UpdateSchedule(c *cli.Context) error {
+	if c.Bool(FlagPatch) {
+		return PatchSchedule(c)
+	}
+	if c.IsSet(FlagRemoveCalendar) || c.IsSet(FlagDryRun) {
+		return fmt.Errorf("options --%s and --%s require --%s", FlagRemoveCalendar, FlagDryRun, FlagPatch)
+	}
 	frontendClient, namespace, scheduleID, err := scheduleBaseArgs(c)
 	if err != nil {
 		return err
 	}
+	// task queue and workflow type are only optional with --patch
+	for _, name := range []string{FlagTaskQueue, FlagWorkflowType} {
+		if _, err := requiredFlag(c, name); err != nil {
+			return err
+		}
+	}
 	ctx, cancel := newContext(c)
 	defer cancel()
 
@@ -303,13 +316,6 @@ func UpdateSchedule(c *cli.Context) error {
 }
 
 func ToggleSchedule(c *cli.Context) error {
-	frontendClient, namespace, scheduleID, err := scheduleBaseArgs(c)
-	if err != nil {
-		return err
-	}
-	ctx, cancel := newContext(c)
-	defer cancel()
-
 	pause, unpause := c.Bool(FlagPause), c.Bool(FlagUnpause)
 	if pause && unpause {
 		return errors.New("Cannot specify both --pause and --unpause")
@@ -323,6 +329,21 @@ func ToggleSchedule(c *cli.Context) error {
 		patch.Unpause = c.String(FlagReason)
 	}
 
+	if bulk, err := isScheduleBulkOperation(c); err != nil {
+		return err
+	} else if bulk && pause {
+		return patchSchedules(c, "pause", patch)
+	} else if bulk {
+		return patchSchedules(c, "unpause", patch)
+	}
+
+	frontendClient, namespace, scheduleID, err := scheduleBaseArgs(c)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := newContext(c)
+	defer cancel()
+
 	req := &workflowservice.PatchScheduleRequest{
 		Namespace:  namespace,
 		ScheduleId: scheduleID,
@@ -340,17 +361,24 @@ func ToggleSchedule(c *cli.Context) error {
 }
 
 func TriggerSchedule(c *cli.Context) error {
-	frontendClient, namespace, scheduleID, err := scheduleBaseArgs(c)
+	overlap, err := getOverlapPolicy(c)
 	if err != nil {
 		return err
 	}
-	ctx, cancel := newContext(c)
-	defer cancel()
+	if bulk, err := isScheduleBulkOperation(c); err != nil {
+		return err
+	} else if bulk {
+		return patchSchedules(c, "trigger", &schedpb.SchedulePatch{
+			TriggerImmediately: &schedpb.TriggerImmediatelyRequest{OverlapPolicy: overlap},
+		})
+	}
 
-	overlap, err := getOverlapPolicy(c)
+	frontendClient, namespace, scheduleID, err := scheduleBaseArgs(c)
 	if err != nil {
 		return err
 	}
+	ctx, cancel := newContext(c)
+	defer cancel()
 
 	req := &workflowservice.PatchScheduleRequest{
 		Namespace:  namespace,
@@ -393,6 +421,9 @@ func BackfillSchedule(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	if c.Bool(FlagDryRun) || c.IsSet(FlagChunk) || c.Bool(FlagWait) {
+		return backfillScheduleInChunks(c, startTime, endTime, overlap)
+	}
 
 	req := &workflowservice.PatchScheduleRequest{
 		Namespace:  namespace,
@@ -454,6 +485,7 @@ func DescribeSchedule(c *cli.Context) error {
 		ScheduleId string
 
 		Specification *schedpb.ScheduleSpec
+		Schedule      string // plain English form of Specification
 
 		StartWorkflow *workflowpb.NewWorkflowExecutionInfo
 		WorkflowType  string   // copy just string to reduce noise
@@ -468,6 +500,9 @@ func DescribeSchedule(c *cli.Context) error {
 		LastRunTime       *time.Time
 		LastRunExecution  *common.WorkflowExecution
 		LastRunActualTime *time.Time
+		NextRunTimes      []*time.Time
+		RecentActions     []scheduleRecentAction
+		RunningWorkflows  int
 
 		Memo             map[string]string // json only
 		SearchAttributes map[string]string // json only
@@ -475,6 +510,7 @@ func DescribeSchedule(c *cli.Context) error {
 
 	s, i := resp.Schedule, resp.Info
 	item.ScheduleId = scheduleID
+	item.Schedule = strings.Join(describeScheduleSpec(s.Spec), "; ")
 	item.Specification = s.Spec
 	uncanonicalizeSpec(item.Specification)
 	if sw := s.Action.GetStartWorkflow(); sw != nil {
@@ -490,6 +526,10 @@ func DescribeSchedule(c *cli.Context) error {
 	item.Info = i
 	if fas := i.FutureActionTimes; len(fas) > 0 {
 		item.NextRunTime = fas[0]
+		item.NextRunTimes = fas
+		if len(fas) > scheduleNextRunTimesCount {
+			item.NextRunTimes = fas[:scheduleNextRunTimesCount]
+		}
 	}
 	if ras := i.RecentActions; len(ras) > 0 {
 		ra := ras[len(ras)-1]
@@ -497,6 +537,8 @@ func DescribeSchedule(c *cli.Context) error {
 		item.LastRunActualTime = ra.ActualTime
 		item.LastRunExecution = ra.StartWorkflowResult
 	}
+	item.RecentActions = describeRecentActions(ctx, frontendClient, namespace, i.RecentActions)
+	item.RunningWorkflows = len(i.RunningWorkflows)
 	if fields := resp.Memo.GetFields(); len(fields) > 0 {
 		item.Memo = make(map[string]string, len(fields))
 		for k, payload := range fields {
@@ -516,12 +558,13 @@ func DescribeSchedule(c *cli.Context) error {
 			"WorkflowType",
 			"State.Paused",
 			"State.Notes",
-			"Info.RunningWorkflows",
+			"RunningWorkflows",
 			"NextRunTime",
 			"LastRunTime",
-			"Specification",
+			"Schedule",
 		},
 		FieldsLong: []string{
+			"Specification",
 			"StartWorkflow.WorkflowId",
 			"StartWorkflow.TaskQueue",
 			"Input",
@@ -537,10 +580,99 @@ func DescribeSchedule(c *cli.Context) error {
 			"Info.InvalidScheduleError",
 		},
 	}
-	return output.PrintItems(c, []interface{}{item}, opts)
+	if err := output.PrintItems(c, []interface{}{item}, opts); err != nil {
+		return err
+	}
+	if output.OutputOption(c.String(output.FlagOutput)) == output.JSON {
+		return nil
+	}
+	return printScheduleActivity(c, item.NextRunTimes, item.RecentActions, i.RunningWorkflows)
+}
+
+// scheduleNextRunTimesCount is the number of future action times shown by describe
+const scheduleNextRunTimesCount = 10
+
+type scheduleRecentAction struct {
+	ScheduleTime *time.Time
+	ActualTime   *time.Time
+	WorkflowId   string
+	RunId        string
+	Status       string
+}
+
+// describeRecentActions looks up the current status of the workflows started by recent actions.
+// The status is Unknown when the workflow can't be described, e.g. once it is past retention.
+func describeRecentActions(ctx context.Context, frontendClient workflowservice.WorkflowServiceClient, namespace string, actions []*schedpb.ScheduleActionResult) []scheduleRecentAction {
+	var out []scheduleRecentAction
+	for _, action := range actions {
+		ra := scheduleRecentAction{
+			ScheduleTime: action.GetScheduleTime(),
+			ActualTime:   action.GetActualTime(),
+			WorkflowId:   action.GetStartWorkflowResult().GetWorkflowId(),
+			RunId:        action.GetStartWorkflowResult().GetRunId(),
+			Status:       "Unknown",
+		}
+		if action.GetStartWorkflowResult() != nil {
+			resp, err := frontendClient.DescribeWorkflowExecution(ctx, &workflowservice.DescribeWorkflowExecutionRequest{
+				Namespace: namespace,
+				Execution: action.GetStartWorkflowResult(),
+			})
+			if err == nil {
+				ra.Status = resp.GetWorkflowExecutionInfo().GetStatus().String()
+			}
+		}
+		out = append(out, ra)
+	}
+	return out
+}
+
+func printScheduleActivity(c *cli.Context, nextRunTimes []*time.Time, recentActions []scheduleRecentAction, runningWorkflows []*common.WorkflowExecution) error {
+	if len(nextRunTimes) > 0 {
+		fmt.Println("\nNext run times:")
+		var items []interface{}
+		for _, t := range nextRunTimes {
+			items = append(items, struct{ RunTime *time.Time }{t})
+		}
+		if err := output.PrintItems(c, items, &output.PrintOptions{Fields: []string{"RunTime"}, ForceFields: true, OutputFormat: output.Table}); err != nil {
+			return err
+		}
+	}
+	if len(recentActions) > 0 {
+		fmt.Println("\nRecent actions:")
+		var items []interface{}
+		for _, ra := range recentActions {
+			items = append(items, ra)
+		}
+		opts := &output.PrintOptions{
+			Fields:       []string{"ScheduleTime", "ActualTime", "WorkflowId", "RunId", "Status"},
+			ForceFields:  true,
+			OutputFormat: output.Table,
+		}
+		if err := output.PrintItems(c, items, opts); err != nil {
+			return err
+		}
+	}
+	if len(runningWorkflows) > 0 {
+		fmt.Println("\nRunning workflows:")
+		var items []interface{}
+		for _, we := range runningWorkflows {
+			items = append(items, we)
+		}
+		opts := &output.PrintOptions{Fields: []string{"WorkflowId", "RunId"}, ForceFields: true, OutputFormat: output.Table}
+		if err := output.PrintItems(c, items, opts); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func DeleteSchedule(c *cli.Context) error {
+	if bulk, err := isScheduleBulkOperation(c); err != nil {
+		return err
+	} else if bulk {
+		return deleteSchedules(c)
+	}
+
 	frontendClient, namespace, scheduleID, err := scheduleBaseArgs(c)
 	if err != nil {
 		return err
@@ -572,6 +704,7 @@ func ListSchedules(c *cli.Context) error {
 	defer cancel()
 
 	missingExtendedInfo := false
+	filter := newScheduleFilter(c)
 
 	paginationFunc := func(npt []byte) ([]interface{}, []byte, error) {
 		req := &workflowservice.ListSchedulesRequest{
@@ -582,8 +715,8 @@ func ListSchedules(c *cli.Context) error {
 		if err != nil {
 			return nil, nil, fmt.Errorf("unable to list schedules: %w", err)
 		}
-		items := make([]interface{}, len(resp.Schedules))
-		for i, sch := range resp.Schedules {
+		items := make([]interface{}, 0, len(resp.Schedules))
+		for _, sch := range resp.Schedules {
 			var item struct {
 				ScheduleId    string
 				Specification *schedpb.ScheduleSpec
@@ -605,6 +738,11 @@ func ListSchedules(c *cli.Context) error {
 			if info == nil {
 				missingExtendedInfo = true
 			}
+			if ok, err := filter.matches(sch); err != nil {
+				return nil, nil, err
+			} else if !ok {
+				continue
+			}
 			item.ScheduleId = sch.ScheduleId
 			item.StartWorkflow.WorkflowType = info.GetWorkflowType().GetName()
 			item.State.Paused = info.GetPaused()
@@ -620,7 +758,7 @@ func ListSchedules(c *cli.Context) error {
 			}
 			item.Specification = info.GetSpec()
 			uncanonicalizeSpec(item.Specification)
-			items[i] = item
+			items = append(items, item)
 		}
 		return items, resp.NextPageToken, nil
 	}
@@ -657,9 +795,8 @@ func uncanonicalizeSpec(spec *schedpb.ScheduleSpec) {
 		}
 		return strings.Join(out, ",")
 	}
-	// Turn StructuredCalenderSpec into CalendarSpec for ease of reading
-	for _, scs := range spec.StructuredCalendar {
-		spec.Calendar = append(spec.Calendar, &schedpb.CalendarSpec{
+	processCalendar := func(scs *schedpb.StructuredCalendarSpec) *schedpb.CalendarSpec {
+		return &schedpb.CalendarSpec{
 			Second:     processField(scs.Second),
 			Minute:     processField(scs.Minute),
 			Hour:       processField(scs.Hour),
@@ -668,7 +805,15 @@ func uncanonicalizeSpec(spec *schedpb.ScheduleSpec) {
 			Year:       processField(scs.Year),
 			DayOfWeek:  processField(scs.DayOfWeek),
 			Comment:    scs.Comment,
-		})
+		}
+	}
+	// Turn StructuredCalenderSpec into CalendarSpec for ease of reading
+	for _, scs := range spec.StructuredCalendar {
+		spec.Calendar = append(spec.Calendar, processCalendar(scs))
 	}
 	spec.StructuredCalendar = nil
+	for _, scs := range spec.ExcludeStructuredCalendar {
+		spec.ExcludeCalendar = append(spec.ExcludeCalendar, processCalendar(scs))
+	}
+	spec.ExcludeStructuredCalendar = nil
 }
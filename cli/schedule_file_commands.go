@@ -0,0 +1,555 @@
+// The MIT License
+//
+// Copyright (c) 2022 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pborman/uuid"
+	"github.com/temporalio/tctl-kit/pkg/color"
+	"github.com/urfave/cli/v2"
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	schedpb "go.temporal.io/api/schedule/v1"
+	"go.temporal.io/api/serviceerror"
+	taskqueuepb "go.temporal.io/api/taskqueue/v1"
+	workflowpb "go.temporal.io/api/workflow/v1"
+	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/sdk/converter"
+	"go.temporal.io/server/common/primitives/timestamp"
+	"go.temporal.io/server/service/worker/scheduler"
+)
+
+// scheduleFile is the definition of a schedule as written in YAML or JSON files.
+// It mirrors schedpb.Schedule with durations, times and payloads in readable form.
+type scheduleFile struct {
+	ID               string                 `yaml:"id" json:"id"`
+	Spec             scheduleFileSpec       `yaml:"spec" json:"spec"`
+	Action           scheduleFileAction     `yaml:"action" json:"action"`
+	Policies         scheduleFilePolicies   `yaml:"policies,omitempty" json:"policies,omitempty"`
+	State            scheduleFileState      `yaml:"state,omitempty" json:"state,omitempty"`
+	Memo             map[string]interface{} `yaml:"memo,omitempty" json:"memo,omitempty"`
+	SearchAttributes map[string]interface{} `yaml:"searchAttributes,omitempty" json:"searchAttributes,omitempty"`
+}
+
+type scheduleFileSpec struct {
+	Calendars        []*scheduleFileCalendar `yaml:"calendars,omitempty" json:"calendars,omitempty"`
+	ExcludeCalendars []*scheduleFileCalendar `yaml:"excludeCalendars,omitempty" json:"excludeCalendars,omitempty"`
+	Cron             []string                `yaml:"cron,omitempty" json:"cron,omitempty"`
+	// Intervals are durations with an optional phase, e.g. 90m or 90m/13m
+	Intervals []string `yaml:"intervals,omitempty" json:"intervals,omitempty"`
+	StartTime string   `yaml:"startTime,omitempty" json:"startTime,omitempty"`
+	EndTime   string   `yaml:"endTime,omitempty" json:"endTime,omitempty"`
+	Jitter    string   `yaml:"jitter,omitempty" json:"jitter,omitempty"`
+	TimeZone  string   `yaml:"timeZone,omitempty" json:"timeZone,omitempty"`
+}
+
+type scheduleFileCalendar struct {
+	Second     string `yaml:"second,omitempty" json:"second,omitempty"`
+	Minute     string `yaml:"minute,omitempty" json:"minute,omitempty"`
+	Hour       string `yaml:"hour,omitempty" json:"hour,omitempty"`
+	DayOfMonth string `yaml:"dayOfMonth,omitempty" json:"dayOfMonth,omitempty"`
+	Month      string `yaml:"month,omitempty" json:"month,omitempty"`
+	Year       string `yaml:"year,omitempty" json:"year,omitempty"`
+	DayOfWeek  string `yaml:"dayOfWeek,omitempty" json:"dayOfWeek,omitempty"`
+	Comment    string `yaml:"comment,omitempty" json:"comment,omitempty"`
+}
+
+type scheduleFileAction struct {
+	WorkflowID       string                 `yaml:"workflowId" json:"workflowId"`
+	WorkflowType     string                 `yaml:"workflowType" json:"workflowType"`
+	TaskQueue        string                 `yaml:"taskQueue" json:"taskQueue"`
+	Input            []interface{}          `yaml:"input,omitempty" json:"input,omitempty"`
+	ExecutionTimeout string                 `yaml:"executionTimeout,omitempty" json:"executionTimeout,omitempty"`
+	RunTimeout       string                 `yaml:"runTimeout,omitempty" json:"runTimeout,omitempty"`
+	TaskTimeout      string                 `yaml:"taskTimeout,omitempty" json:"taskTimeout,omitempty"`
+	Memo             map[string]interface{} `yaml:"memo,omitempty" json:"memo,omitempty"`
+	SearchAttributes map[string]interface{} `yaml:"searchAttributes,omitempty" json:"searchAttributes,omitempty"`
+}
+
+type scheduleFilePolicies struct {
+	OverlapPolicy  string `yaml:"overlapPolicy,omitempty" json:"overlapPolicy,omitempty"`
+	CatchupWindow  string `yaml:"catchupWindow,omitempty" json:"catchupWindow,omitempty"`
+	PauseOnFailure bool   `yaml:"pauseOnFailure,omitempty" json:"pauseOnFailure,omitempty"`
+}
+
+type scheduleFileState struct {
+	Paused bool   `yaml:"paused,omitempty" json:"paused,omitempty"`
+	Notes  string `yaml:"notes,omitempty" json:"notes,omitempty"`
+	// RemainingActions limits the number of actions when set
+	RemainingActions *int64 `yaml:"remainingActions,omitempty" json:"remainingActions,omitempty"`
+}
+
+// ApplySchedule creates the schedule defined in a file, or updates it if it differs from the file
+func ApplySchedule(c *cli.Context) error {
+	frontendClient := cFactory.FrontendClient(c)
+	namespace, err := requiredFlag(c, FlagNamespace)
+	if err != nil {
+		return err
+	}
+	file, err := readScheduleFile(c.String(FlagFile))
+	if err != nil {
+		return err
+	}
+	sched, memo, sa, err := file.toSchedule()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+	resp, err := frontendClient.DescribeSchedule(ctx, &workflowservice.DescribeScheduleRequest{
+		Namespace:  namespace,
+		ScheduleId: file.ID,
+	})
+	if _, ok := err.(*serviceerror.NotFound); ok {
+		if c.Bool(FlagDryRun) {
+			fmt.Println(color.Yellow(c, "Schedule %s would be created", file.ID))
+			return nil
+		}
+		_, err = frontendClient.CreateSchedule(ctx, &workflowservice.CreateScheduleRequest{
+			Namespace:        namespace,
+			ScheduleId:       file.ID,
+			Schedule:         sched,
+			Identity:         getCliIdentity(),
+			RequestId:        uuid.New(),
+			Memo:             memo,
+			SearchAttributes: sa,
+		})
+		if err != nil {
+			return fmt.Errorf("unable to create schedule: %w", err)
+		}
+		fmt.Println(color.Green(c, "Schedule %s created", file.ID))
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("unable to describe schedule: %w", err)
+	}
+
+	// the server keeps the spec in canonical form, so compare against the canonical form of the file
+	compiledSpec, err := scheduler.NewCompiledSpec(sched.Spec)
+	if err != nil {
+		return fmt.Errorf("invalid schedule spec: %w", err)
+	}
+	desiredSched := *sched
+	desiredSched.Spec = compiledSpec.CanonicalForm()
+	desired, err := scheduleFileFromSchedule(file.ID, &desiredSched, memo, sa)
+	if err != nil {
+		return err
+	}
+	current, err := scheduleFileFromSchedule(file.ID, resp.GetSchedule(), resp.GetMemo(), resp.GetSearchAttributes())
+	if err != nil {
+		return err
+	}
+
+	if !jsonEqual(desired.Memo, current.Memo) || !jsonEqual(desired.SearchAttributes, current.SearchAttributes) {
+		fmt.Println(color.Yellow(c, "Memo and search attributes of a schedule are only set when it is created, their changes are ignored"))
+	}
+	changed := diffScheduleFiles(desired, current)
+	if len(changed) == 0 {
+		fmt.Println(color.Green(c, "Schedule %s is up to date", file.ID))
+		return nil
+	}
+	if c.Bool(FlagDryRun) {
+		fmt.Println(color.Yellow(c, "Schedule %s would be updated, changed: %s", file.ID, strings.Join(changed, ", ")))
+		return nil
+	}
+
+	_, err = frontendClient.UpdateSchedule(ctx, &workflowservice.UpdateScheduleRequest{
+		Namespace:     namespace,
+		ScheduleId:    file.ID,
+		Schedule:      sched,
+		ConflictToken: resp.GetConflictToken(),
+		Identity:      getCliIdentity(),
+		RequestId:     uuid.New(),
+	})
+	if err != nil {
+		return fmt.Errorf("unable to update schedule: %w", err)
+	}
+	fmt.Println(color.Green(c, "Schedule %s updated, changed: %s", file.ID, strings.Join(changed, ", ")))
+	return nil
+}
+
+// ExportSchedule writes the definition of an existing schedule in the format read by apply
+func ExportSchedule(c *cli.Context) error {
+	frontendClient, namespace, scheduleID, err := scheduleBaseArgs(c)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := newContext(c)
+	defer cancel()
+
+	resp, err := frontendClient.DescribeSchedule(ctx, &workflowservice.DescribeScheduleRequest{
+		Namespace:  namespace,
+		ScheduleId: scheduleID,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to describe schedule: %w", err)
+	}
+	file, err := scheduleFileFromSchedule(scheduleID, resp.GetSchedule(), resp.GetMemo(), resp.GetSearchAttributes())
+	if err != nil {
+		return err
+	}
+
+	return writeDefinition(c, "schedule", file)
+}
+
+func readScheduleFile(path string) (*scheduleFile, error) {
+	var file scheduleFile
+	if err := readDefinitionFile(path, "schedule", &file); err != nil {
+		return nil, err
+	}
+	if file.ID == "" {
+		return nil, fmt.Errorf("schedule file has no id")
+	}
+	return &file, nil
+}
+
+// toSchedule builds the schedule along with its memo and search attributes
+func (f *scheduleFile) toSchedule() (*schedpb.Schedule, *commonpb.Memo, *commonpb.SearchAttributes, error) {
+	spec, err := f.Spec.toSpec()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	action, err := f.Action.toAction()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	policies, err := f.Policies.toPolicies()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	state := &schedpb.ScheduleState{
+		Paused: f.State.Paused,
+		Notes:  f.State.Notes,
+	}
+	if f.State.RemainingActions != nil {
+		state.LimitedActions = true
+		state.RemainingActions = *f.State.RemainingActions
+	}
+
+	memo, err := encodeMemo(f.Memo)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("unable to encode memo: %w", err)
+	}
+	sa, err := encodeSearchAttributes(f.SearchAttributes)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("unable to encode search attributes: %w", err)
+	}
+	return &schedpb.Schedule{Spec: spec, Action: action, Policies: policies, State: state}, memo, sa, nil
+}
+
+func (s *scheduleFileSpec) toSpec() (*schedpb.ScheduleSpec, error) {
+	now := time.Now()
+	out := &schedpb.ScheduleSpec{
+		CronString:   s.Cron,
+		TimezoneName: s.TimeZone,
+	}
+	for _, cal := range s.Calendars {
+		out.Calendar = append(out.Calendar, cal.toCalendar())
+	}
+	for _, cal := range s.ExcludeCalendars {
+		out.ExcludeCalendar = append(out.ExcludeCalendar, cal.toCalendar())
+	}
+	for _, i := range s.Intervals {
+		interval, err := buildIntervalSpec(i)
+		if err != nil {
+			return nil, fmt.Errorf("invalid interval %q: %w", i, err)
+		}
+		out.Interval = append(out.Interval, interval)
+	}
+	if s.StartTime != "" {
+		t, err := parseTime(s.StartTime, time.Time{}, now)
+		if err != nil {
+			return nil, err
+		}
+		out.StartTime = timestamp.TimePtr(t)
+	}
+	if s.EndTime != "" {
+		t, err := parseTime(s.EndTime, time.Time{}, now)
+		if err != nil {
+			return nil, err
+		}
+		out.EndTime = timestamp.TimePtr(t)
+	}
+	var err error
+	if out.Jitter, err = parseOptionalDuration(s.Jitter); err != nil {
+		return nil, fmt.Errorf("invalid jitter: %w", err)
+	}
+	if s.TimeZone != "" {
+		if _, err := time.LoadLocation(s.TimeZone); err != nil {
+			return nil, fmt.Errorf("unknown time zone name %q", s.TimeZone)
+		}
+	}
+	return out, nil
+}
+
+func (cal *scheduleFileCalendar) toCalendar() *schedpb.CalendarSpec {
+	return &schedpb.CalendarSpec{
+		Second:     cal.Second,
+		Minute:     cal.Minute,
+		Hour:       cal.Hour,
+		DayOfMonth: cal.DayOfMonth,
+		Month:      cal.Month,
+		Year:       cal.Year,
+		DayOfWeek:  cal.DayOfWeek,
+		Comment:    cal.Comment,
+	}
+}
+
+func (a *scheduleFileAction) toAction() (*schedpb.ScheduleAction, error) {
+	if a.WorkflowType == "" || a.TaskQueue == "" {
+		return nil, fmt.Errorf("schedule action requires workflowType and taskQueue")
+	}
+	workflow := &workflowpb.NewWorkflowExecutionInfo{
+		WorkflowId:   a.WorkflowID,
+		WorkflowType: &commonpb.WorkflowType{Name: a.WorkflowType},
+		TaskQueue:    &taskqueuepb.TaskQueue{Name: a.TaskQueue},
+	}
+	var err error
+	if len(a.Input) > 0 {
+		if workflow.Input, err = customDataConverter().ToPayloads(a.Input...); err != nil {
+			return nil, fmt.Errorf("unable to encode input: %w", err)
+		}
+	}
+	if workflow.WorkflowExecutionTimeout, err = parseOptionalDuration(a.ExecutionTimeout); err != nil {
+		return nil, fmt.Errorf("invalid execution timeout: %w", err)
+	}
+	if workflow.WorkflowRunTimeout, err = parseOptionalDuration(a.RunTimeout); err != nil {
+		return nil, fmt.Errorf("invalid run timeout: %w", err)
+	}
+	if workflow.WorkflowTaskTimeout, err = parseOptionalDuration(a.TaskTimeout); err != nil {
+		return nil, fmt.Errorf("invalid task timeout: %w", err)
+	}
+	if workflow.Memo, err = encodeMemo(a.Memo); err != nil {
+		return nil, fmt.Errorf("unable to encode workflow memo: %w", err)
+	}
+	if workflow.SearchAttributes, err = encodeSearchAttributes(a.SearchAttributes); err != nil {
+		return nil, fmt.Errorf("unable to encode workflow search attributes: %w", err)
+	}
+	return &schedpb.ScheduleAction{
+		Action: &schedpb.ScheduleAction_StartWorkflow{StartWorkflow: workflow},
+	}, nil
+}
+
+func (p *scheduleFilePolicies) toPolicies() (*schedpb.SchedulePolicies, error) {
+	overlap, err := stringToEnum(p.OverlapPolicy, enumspb.ScheduleOverlapPolicy_value)
+	if err != nil {
+		return nil, err
+	}
+	out := &schedpb.SchedulePolicies{
+		OverlapPolicy:  enumspb.ScheduleOverlapPolicy(overlap),
+		PauseOnFailure: p.PauseOnFailure,
+	}
+	if out.CatchupWindow, err = parseOptionalDuration(p.CatchupWindow); err != nil {
+		return nil, fmt.Errorf("invalid catchup window: %w", err)
+	}
+	return out, nil
+}
+
+// scheduleFileFromSchedule converts a schedule to its file form, decoding payloads and
+// turning structured calendars back into calendars
+func scheduleFileFromSchedule(id string, sched *schedpb.Schedule, memo *commonpb.Memo, sa *commonpb.SearchAttributes) (*scheduleFile, error) {
+	file := &scheduleFile{ID: id}
+
+	if sched.GetSpec() != nil {
+		spec := *sched.GetSpec()
+		uncanonicalizeSpec(&spec)
+		for _, cal := range spec.Calendar {
+			file.Spec.Calendars = append(file.Spec.Calendars, scheduleFileCalendarFromSpec(cal))
+		}
+		for _, cal := range spec.ExcludeCalendar {
+			file.Spec.ExcludeCalendars = append(file.Spec.ExcludeCalendars, scheduleFileCalendarFromSpec(cal))
+		}
+		file.Spec.Cron = spec.CronString
+		for _, i := range spec.Interval {
+			interval := formatDuration(timestamp.DurationValue(i.GetInterval()))
+			if phase := timestamp.DurationValue(i.GetPhase()); phase > 0 {
+				interval += "/" + formatDuration(phase)
+			}
+			file.Spec.Intervals = append(file.Spec.Intervals, interval)
+		}
+		if spec.StartTime != nil {
+			file.Spec.StartTime = spec.StartTime.UTC().Format(time.RFC3339)
+		}
+		if spec.EndTime != nil {
+			file.Spec.EndTime = spec.EndTime.UTC().Format(time.RFC3339)
+		}
+		file.Spec.Jitter = formatDuration(timestamp.DurationValue(spec.Jitter))
+		file.Spec.TimeZone = spec.TimezoneName
+	}
+
+	if workflow := sched.GetAction().GetStartWorkflow(); workflow != nil {
+		file.Action = scheduleFileAction{
+			WorkflowID:       workflow.GetWorkflowId(),
+			WorkflowType:     workflow.GetWorkflowType().GetName(),
+			TaskQueue:        workflow.GetTaskQueue().GetName(),
+			ExecutionTimeout: formatDuration(timestamp.DurationValue(workflow.GetWorkflowExecutionTimeout())),
+			RunTimeout:       formatDuration(timestamp.DurationValue(workflow.GetWorkflowRunTimeout())),
+			TaskTimeout:      formatDuration(timestamp.DurationValue(workflow.GetWorkflowTaskTimeout())),
+		}
+		for _, p := range workflow.GetInput().GetPayloads() {
+			value, err := decodePayloadValue(customDataConverter(), p)
+			if err != nil {
+				return nil, fmt.Errorf("unable to decode input: %w", err)
+			}
+			file.Action.Input = append(file.Action.Input, value)
+		}
+		var err error
+		if file.Action.Memo, err = decodePayloadMap(customDataConverter(), workflow.GetMemo().GetFields()); err != nil {
+			return nil, fmt.Errorf("unable to decode workflow memo: %w", err)
+		}
+		if file.Action.SearchAttributes, err = decodePayloadMap(defaultDataConverter(), workflow.GetSearchAttributes().GetIndexedFields()); err != nil {
+			return nil, fmt.Errorf("unable to decode workflow search attributes: %w", err)
+		}
+	}
+
+	policies := sched.GetPolicies()
+	overlap := policies.GetOverlapPolicy()
+	if overlap == enumspb.SCHEDULE_OVERLAP_POLICY_UNSPECIFIED {
+		overlap = enumspb.SCHEDULE_OVERLAP_POLICY_SKIP
+	}
+	file.Policies = scheduleFilePolicies{
+		OverlapPolicy:  overlap.String(),
+		CatchupWindow:  formatDuration(timestamp.DurationValue(policies.GetCatchupWindow())),
+		PauseOnFailure: policies.GetPauseOnFailure(),
+	}
+
+	file.State = scheduleFileState{
+		Paused: sched.GetState().GetPaused(),
+		Notes:  sched.GetState().GetNotes(),
+	}
+	if sched.GetState().GetLimitedActions() {
+		remaining := sched.GetState().GetRemainingActions()
+		file.State.RemainingActions = &remaining
+	}
+
+	var err error
+	if file.Memo, err = decodePayloadMap(customDataConverter(), memo.GetFields()); err != nil {
+		return nil, fmt.Errorf("unable to decode memo: %w", err)
+	}
+	if file.SearchAttributes, err = decodePayloadMap(defaultDataConverter(), sa.GetIndexedFields()); err != nil {
+		return nil, fmt.Errorf("unable to decode search attributes: %w", err)
+	}
+	return file, nil
+}
+
+func scheduleFileCalendarFromSpec(cal *schedpb.CalendarSpec) *scheduleFileCalendar {
+	return &scheduleFileCalendar{
+		Second:     cal.GetSecond(),
+		Minute:     cal.GetMinute(),
+		Hour:       cal.GetHour(),
+		DayOfMonth: cal.GetDayOfMonth(),
+		Month:      cal.GetMonth(),
+		Year:       cal.GetYear(),
+		DayOfWeek:  cal.GetDayOfWeek(),
+		Comment:    cal.GetComment(),
+	}
+}
+
+// diffScheduleFiles returns the sections of the schedule that differ. Remaining actions go
+// down as the schedule runs, so they are only compared when limiting actions is turned on or off.
+func diffScheduleFiles(desired *scheduleFile, current *scheduleFile) []string {
+	desiredState, currentState := desired.State, current.State
+	if desiredState.RemainingActions != nil && currentState.RemainingActions != nil {
+		desiredState.RemainingActions, currentState.RemainingActions = nil, nil
+	}
+
+	var changed []string
+	if !jsonEqual(desired.Spec, current.Spec) {
+		changed = append(changed, "spec")
+	}
+	if !jsonEqual(desired.Action, current.Action) {
+		changed = append(changed, "action")
+	}
+	if !jsonEqual(desired.Policies, current.Policies) {
+		changed = append(changed, "policies")
+	}
+	if !jsonEqual(desiredState, currentState) {
+		changed = append(changed, "state")
+	}
+	return changed
+}
+
+// jsonEqual compares values by their JSON encoding, which ignores the difference between
+// numbers decoded from YAML and JSON
+func jsonEqual(a interface{}, b interface{}) bool {
+	aJSON, aErr := json.Marshal(a)
+	bJSON, bErr := json.Marshal(b)
+	return aErr == nil && bErr == nil && bytes.Equal(aJSON, bJSON)
+}
+
+func decodePayloadValue(dc converter.DataConverter, payload *commonpb.Payload) (interface{}, error) {
+	var value interface{}
+	if err := dc.FromPayload(payload, &value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+func decodePayloadMap(dc converter.DataConverter, fields map[string]*commonpb.Payload) (map[string]interface{}, error) {
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	out := make(map[string]interface{}, len(fields))
+	for k, p := range fields {
+		value, err := decodePayloadValue(dc, p)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", k, err)
+		}
+		out[k] = value
+	}
+	return out, nil
+}
+
+func parseOptionalDuration(s string) (*time.Duration, error) {
+	if s == "" {
+		return nil, nil
+	}
+	d, err := timestamp.ParseDuration(s)
+	if err != nil {
+		return nil, err
+	}
+	return timestamp.DurationPtr(d), nil
+}
+
+// formatDuration formats a duration without trailing zero units, e.g. 1h instead of 1h0m0s.
+// Zero durations are formatted as an empty string.
+func formatDuration(d time.Duration) string {
+	if d == 0 {
+		return ""
+	}
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
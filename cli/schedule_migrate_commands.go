@@ -0,0 +1,310 @@
+// The MIT License
+//
+// Copyright (c) 2022 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cli
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/pborman/uuid"
+	"github.com/temporalio/tctl-kit/pkg/color"
+	"github.com/temporalio/tctl-kit/pkg/output"
+	"github.com/urfave/cli/v2"
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	historypb "go.temporal.io/api/history/v1"
+	schedpb "go.temporal.io/api/schedule/v1"
+	"go.temporal.io/api/serviceerror"
+	workflowpb "go.temporal.io/api/workflow/v1"
+	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/server/service/worker/scheduler"
+)
+
+const (
+	cronMigrationWouldCreate      = "WouldCreate"
+	cronMigrationCreated          = "Created"
+	cronMigrationTerminated       = "CreatedAndTerminated"
+	cronMigrationExists           = "ScheduleExists"
+	cronMigrationExistsTerminated = "ScheduleExistsAndTerminated"
+	cronMigrationFailed           = "Failed"
+)
+
+// cronMigration is a row of the migrate-cron report
+type cronMigration struct {
+	WorkflowId   string
+	RunId        string
+	WorkflowType string
+	TaskQueue    string
+	CronSchedule string
+	ScheduleId   string
+	Status       string
+	Error        string
+
+	started *historypb.WorkflowExecutionStartedEventAttributes
+}
+
+// MigrateCronWorkflows creates a schedule for every running cron workflow matching the query,
+// with the same cron string and workflow options as the start event of the workflow
+func MigrateCronWorkflows(c *cli.Context) error {
+	sdkClient, err := getSDKClient(c)
+	if err != nil {
+		return err
+	}
+	frontendClient := cFactory.FrontendClient(c)
+	namespace, err := requiredFlag(c, FlagNamespace)
+	if err != nil {
+		return err
+	}
+
+	query := "ExecutionStatus = 'Running'"
+	if c.IsSet(FlagQuery) {
+		query = fmt.Sprintf("(%s) AND %s", c.String(FlagQuery), query)
+	}
+	var executions []*workflowpb.WorkflowExecutionInfo
+	var npt []byte
+	for {
+		page, next, err := listWorkflows(c, sdkClient, npt, query)
+		if err != nil {
+			return err
+		}
+		for _, item := range page {
+			executions = append(executions, item.(*workflowpb.WorkflowExecutionInfo))
+		}
+		if npt = next; len(npt) == 0 {
+			break
+		}
+	}
+
+	// only the start event tells whether a workflow runs on a cron schedule
+	started := make([]*cronMigration, len(executions))
+	var failed int32
+	runConcurrently(len(executions), c.Int(FlagConcurrency), c.Float64(FlagRPS), func(i int) {
+		execution := executions[i].GetExecution()
+		m := &cronMigration{
+			WorkflowId:   execution.GetWorkflowId(),
+			RunId:        execution.GetRunId(),
+			WorkflowType: executions[i].GetType().GetName(),
+			ScheduleId:   execution.GetWorkflowId(),
+		}
+		attrs, err := getWorkflowStartedAttributes(c, frontendClient, namespace, execution)
+		if err != nil {
+			atomic.AddInt32(&failed, 1)
+			m.Status = cronMigrationFailed
+			m.Error = err.Error()
+		} else if attrs.GetCronSchedule() == "" {
+			return
+		} else {
+			m.started = attrs
+			m.CronSchedule = attrs.GetCronSchedule()
+			m.TaskQueue = attrs.GetTaskQueue().GetName()
+		}
+		started[i] = m
+	})
+	var migrations []*cronMigration
+	for _, m := range started {
+		if m != nil {
+			migrations = append(migrations, m)
+		}
+	}
+	if len(migrations) == 0 {
+		fmt.Println(color.Yellow(c, "No running cron workflows match the query"))
+		return nil
+	}
+
+	if c.Bool(FlagDryRun) {
+		for _, m := range migrations {
+			if m.Status != cronMigrationFailed {
+				if _, err := scheduler.NewCompiledSpec(cronMigrationSchedule(m).Spec); err != nil {
+					m.Status = cronMigrationFailed
+					m.Error = fmt.Sprintf("invalid cron schedule: %v", err)
+					failed++
+				} else {
+					m.Status = cronMigrationWouldCreate
+				}
+			}
+		}
+		return printCronMigrations(c, migrations, failed)
+	}
+
+	promptMsg := fmt.Sprintf("Will create %v schedules", color.Yellow(c, "%v", len(migrations)-int(failed)))
+	if c.Bool(FlagTerminateOld) {
+		promptMsg += " and terminate their cron workflows"
+	}
+	if !promptYes(promptMsg+". Continue? Y/N", c.Bool(FlagYes)) {
+		return nil
+	}
+
+	runConcurrently(len(migrations), c.Int(FlagConcurrency), c.Float64(FlagRPS), func(i int) {
+		m := migrations[i]
+		if m.Status == cronMigrationFailed {
+			return
+		}
+		if err := migrateCronWorkflow(c, frontendClient, namespace, m); err != nil {
+			atomic.AddInt32(&failed, 1)
+			m.Status = cronMigrationFailed
+			m.Error = err.Error()
+		}
+	})
+	return printCronMigrations(c, migrations, failed)
+}
+
+// migrateCronWorkflow creates the schedule of a cron workflow and, with --terminate-old, terminates the
+// workflow once the schedule can be described. A schedule that already exists is left as it is.
+func migrateCronWorkflow(c *cli.Context, frontendClient workflowservice.WorkflowServiceClient, namespace string, m *cronMigration) error {
+	ctx, cancel := newContext(c)
+	defer cancel()
+
+	_, err := frontendClient.CreateSchedule(ctx, &workflowservice.CreateScheduleRequest{
+		Namespace:  namespace,
+		ScheduleId: m.ScheduleId,
+		Schedule:   cronMigrationSchedule(m),
+		Identity:   getCliIdentity(),
+		RequestId:  uuid.New(),
+	})
+	exists := false
+	if _, ok := err.(*serviceerror.WorkflowExecutionAlreadyStarted); ok {
+		exists = true
+		m.Status = cronMigrationExists
+	} else if err != nil {
+		return fmt.Errorf("unable to create schedule: %w", err)
+	} else {
+		m.Status = cronMigrationCreated
+	}
+	if !c.Bool(FlagTerminateOld) {
+		return nil
+	}
+
+	resp, err := frontendClient.DescribeSchedule(ctx, &workflowservice.DescribeScheduleRequest{
+		Namespace:  namespace,
+		ScheduleId: m.ScheduleId,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to confirm schedule, cron workflow not terminated: %w", err)
+	}
+	// a schedule left by an earlier run of migrate-cron still needs its cron workflow terminated,
+	// but a schedule that was not made from this workflow means the workflow is not migrated
+	if exists && !isCronMigrationSchedule(resp.GetSchedule(), m) {
+		return nil
+	}
+	// the cron workflow may have moved on to its next run since it was listed,
+	// so the current run of its chain is terminated rather than the listed one
+	_, err = frontendClient.TerminateWorkflowExecution(ctx, &workflowservice.TerminateWorkflowExecutionRequest{
+		Namespace: namespace,
+		WorkflowExecution: &commonpb.WorkflowExecution{
+			WorkflowId: m.WorkflowId,
+		},
+		FirstExecutionRunId: m.started.GetFirstExecutionRunId(),
+		Reason:              fmt.Sprintf("migrated to schedule %s", m.ScheduleId),
+		Identity:            getCliIdentity(),
+	})
+	if err != nil {
+		return fmt.Errorf("schedule created but unable to terminate cron workflow: %w", err)
+	}
+	if exists {
+		m.Status = cronMigrationExistsTerminated
+	} else {
+		m.Status = cronMigrationTerminated
+	}
+	return nil
+}
+
+// isCronMigrationSchedule reports whether an existing schedule was created by migrating the cron workflow
+func isCronMigrationSchedule(sched *schedpb.Schedule, m *cronMigration) bool {
+	if sched.GetState().GetNotes() == cronMigrationSchedule(m).GetState().GetNotes() {
+		return true
+	}
+	action := sched.GetAction().GetStartWorkflow()
+	return action.GetWorkflowId() == m.WorkflowId && action.GetWorkflowType().GetName() == m.started.GetWorkflowType().GetName()
+}
+
+// cronMigrationSchedule builds the schedule of a cron workflow. The execution timeout of a cron workflow covers
+// all of its runs, so it is not copied to the action.
+func cronMigrationSchedule(m *cronMigration) *schedpb.Schedule {
+	attrs := m.started
+	return &schedpb.Schedule{
+		Spec: &schedpb.ScheduleSpec{
+			CronString: []string{attrs.GetCronSchedule()},
+		},
+		Action: &schedpb.ScheduleAction{
+			Action: &schedpb.ScheduleAction_StartWorkflow{
+				StartWorkflow: &workflowpb.NewWorkflowExecutionInfo{
+					WorkflowId:          m.WorkflowId,
+					WorkflowType:        attrs.GetWorkflowType(),
+					TaskQueue:           attrs.GetTaskQueue(),
+					Input:               attrs.GetInput(),
+					WorkflowRunTimeout:  attrs.GetWorkflowRunTimeout(),
+					WorkflowTaskTimeout: attrs.GetWorkflowTaskTimeout(),
+					RetryPolicy:         attrs.GetRetryPolicy(),
+					Memo:                attrs.GetMemo(),
+					SearchAttributes:    attrs.GetSearchAttributes(),
+					Header:              attrs.GetHeader(),
+				},
+			},
+		},
+		Policies: &schedpb.SchedulePolicies{
+			// like a cron workflow, don't start a run while the previous one is still running
+			OverlapPolicy: enumspb.SCHEDULE_OVERLAP_POLICY_SKIP,
+		},
+		State: &schedpb.ScheduleState{
+			Notes: fmt.Sprintf("migrated from cron workflow %s", m.WorkflowId),
+		},
+	}
+}
+
+func getWorkflowStartedAttributes(c *cli.Context, frontendClient workflowservice.WorkflowServiceClient, namespace string, execution *commonpb.WorkflowExecution) (*historypb.WorkflowExecutionStartedEventAttributes, error) {
+	ctx, cancel := newContext(c)
+	defer cancel()
+	resp, err := frontendClient.GetWorkflowExecutionHistory(ctx, &workflowservice.GetWorkflowExecutionHistoryRequest{
+		Namespace:       namespace,
+		Execution:       execution,
+		MaximumPageSize: 1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to get workflow history: %w", err)
+	}
+	events := resp.GetHistory().GetEvents()
+	if len(events) == 0 || events[0].GetWorkflowExecutionStartedEventAttributes() == nil {
+		return nil, fmt.Errorf("workflow history doesn't start with a started event")
+	}
+	return events[0].GetWorkflowExecutionStartedEventAttributes(), nil
+}
+
+func printCronMigrations(c *cli.Context, migrations []*cronMigration, failed int32) error {
+	items := make([]interface{}, len(migrations))
+	for i, m := range migrations {
+		items[i] = m
+	}
+	opts := &output.PrintOptions{
+		Fields:     []string{"WorkflowId", "CronSchedule", "ScheduleId", "Status", "Error"},
+		FieldsLong: []string{"RunId", "WorkflowType", "TaskQueue"},
+	}
+	if err := output.PrintItems(c, items, opts); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("unable to migrate %d of %d cron workflows", failed, len(migrations))
+	}
+	return nil
+}
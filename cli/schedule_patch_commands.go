@@ -0,0 +1,283 @@
+// The MIT License
+//
+// Copyright (c) 2022 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pborman/uuid"
+	"github.com/temporalio/tctl-kit/pkg/color"
+	"github.com/urfave/cli/v2"
+	commonpb "go.temporal.io/api/common/v1"
+	schedpb "go.temporal.io/api/schedule/v1"
+	taskqueuepb "go.temporal.io/api/taskqueue/v1"
+	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/server/common/primitives/timestamp"
+	"go.temporal.io/server/service/worker/scheduler"
+)
+
+// PatchSchedule changes only the parts of a schedule given by flags, keeping the rest of its current definition
+func PatchSchedule(c *cli.Context) error {
+	frontendClient, namespace, scheduleID, err := scheduleBaseArgs(c)
+	if err != nil {
+		return err
+	}
+	for _, name := range []string{FlagMemo, FlagMemoFile, FlagSearchAttribute} {
+		if c.IsSet(name) {
+			return fmt.Errorf("option --%s can't be updated: memo and search attributes of a schedule are only set when it is created", name)
+		}
+	}
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+	resp, err := frontendClient.DescribeSchedule(ctx, &workflowservice.DescribeScheduleRequest{
+		Namespace:  namespace,
+		ScheduleId: scheduleID,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to describe schedule: %w", err)
+	}
+
+	current, err := scheduleFileFromSchedule(scheduleID, resp.GetSchedule(), nil, nil)
+	if err != nil {
+		return err
+	}
+	sched := resp.GetSchedule()
+	if sched == nil {
+		sched = &schedpb.Schedule{}
+	}
+	if err := patchScheduleSpec(c, sched); err != nil {
+		return err
+	}
+	if err := patchScheduleAction(c, sched); err != nil {
+		return err
+	}
+	if err := patchSchedulePolicies(c, sched); err != nil {
+		return err
+	}
+	patchScheduleState(c, sched)
+
+	// the server keeps the spec in canonical form, so compare the canonical form of the patched spec
+	compiledSpec, err := scheduler.NewCompiledSpec(sched.Spec)
+	if err != nil {
+		return fmt.Errorf("invalid schedule spec: %w", err)
+	}
+	patchedSched := *sched
+	patchedSched.Spec = compiledSpec.CanonicalForm()
+	patched, err := scheduleFileFromSchedule(scheduleID, &patchedSched, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	changes, err := diffFields(current, patched)
+	if err != nil {
+		return err
+	}
+	if len(changes) == 0 {
+		fmt.Println(color.Green(c, "Schedule %s is up to date", scheduleID))
+		return nil
+	}
+	fmt.Printf("Changes to schedule %s:\n", scheduleID)
+	for _, change := range changes {
+		fmt.Printf("  %s\n", change)
+	}
+	if c.Bool(FlagDryRun) {
+		return nil
+	}
+
+	_, err = frontendClient.UpdateSchedule(ctx, &workflowservice.UpdateScheduleRequest{
+		Namespace:     namespace,
+		ScheduleId:    scheduleID,
+		Schedule:      sched,
+		ConflictToken: resp.GetConflictToken(),
+		Identity:      getCliIdentity(),
+		RequestId:     uuid.New(),
+	})
+	if err != nil {
+		return fmt.Errorf("unable to update schedule: %w", err)
+	}
+
+	fmt.Println(color.Green(c, "Schedule updated"))
+	return nil
+}
+
+// patchScheduleSpec adds the given calendars, cron strings and intervals to the spec, removes the
+// calendars given by --remove-calendar and replaces the start and end time, jitter and time zone
+func patchScheduleSpec(c *cli.Context, sched *schedpb.Schedule) error {
+	if sched.Spec == nil {
+		sched.Spec = &schedpb.ScheduleSpec{}
+	}
+	spec := sched.Spec
+
+	for _, s := range c.StringSlice(FlagRemoveCalendar) {
+		if err := removeScheduleCalendar(spec, s); err != nil {
+			return err
+		}
+	}
+
+	patch, err := buildScheduleSpec(c)
+	if err != nil {
+		return err
+	}
+	spec.Calendar = append(spec.Calendar, patch.Calendar...)
+	spec.CronString = append(spec.CronString, patch.CronString...)
+	spec.Interval = append(spec.Interval, patch.Interval...)
+	if c.IsSet(FlagStartTime) {
+		spec.StartTime = patch.StartTime
+	}
+	if c.IsSet(FlagEndTime) {
+		spec.EndTime = patch.EndTime
+	}
+	if c.IsSet(FlagJitter) {
+		spec.Jitter = patch.Jitter
+	}
+	if c.IsSet(FlagTimeZone) {
+		spec.TimezoneName = patch.TimezoneName
+	}
+	return nil
+}
+
+// removeScheduleCalendar removes the calendar matching s from the spec. The spec returned by the server
+// only has structured calendars, so s is compared in the same form, ignoring comments.
+func removeScheduleCalendar(spec *schedpb.ScheduleSpec, s string) error {
+	cal, err := buildCalendarSpec(s)
+	if err != nil {
+		return fmt.Errorf("invalid calendar %s: %w", s, err)
+	}
+	compiled, err := scheduler.NewCompiledSpec(&schedpb.ScheduleSpec{Calendar: []*schedpb.CalendarSpec{cal}})
+	if err != nil {
+		return fmt.Errorf("invalid calendar %s: %w", s, err)
+	}
+	remove := *compiled.CanonicalForm().StructuredCalendar[0]
+	remove.Comment = ""
+	cal.Comment = ""
+
+	for i, existing := range spec.StructuredCalendar {
+		candidate := *existing
+		candidate.Comment = ""
+		if candidate.Equal(&remove) {
+			spec.StructuredCalendar = append(spec.StructuredCalendar[:i], spec.StructuredCalendar[i+1:]...)
+			return nil
+		}
+	}
+	for i, existing := range spec.Calendar {
+		candidate := *existing
+		candidate.Comment = ""
+		if candidate.Equal(cal) {
+			spec.Calendar = append(spec.Calendar[:i], spec.Calendar[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("schedule has no calendar matching %s", s)
+}
+
+func patchScheduleAction(c *cli.Context, sched *schedpb.Schedule) error {
+	actionFlags := []string{
+		FlagTaskQueue, FlagWorkflowType, FlagWorkflowID,
+		FlagWorkflowExecutionTimeout, FlagWorkflowRunTimeout, FlagWorkflowTaskTimeout,
+		FlagInput, FlagInputFile,
+	}
+	if !anyFlagSet(c, actionFlags) {
+		return nil
+	}
+	workflow := sched.GetAction().GetStartWorkflow()
+	if workflow == nil {
+		return errors.New("schedule action is not starting a workflow, its workflow options can't be updated")
+	}
+
+	if c.IsSet(FlagTaskQueue) {
+		workflow.TaskQueue = &taskqueuepb.TaskQueue{Name: c.String(FlagTaskQueue)}
+	}
+	if c.IsSet(FlagWorkflowType) {
+		workflow.WorkflowType = &commonpb.WorkflowType{Name: c.String(FlagWorkflowType)}
+	}
+	if c.IsSet(FlagWorkflowID) {
+		workflow.WorkflowId = c.String(FlagWorkflowID)
+	}
+	if c.IsSet(FlagWorkflowExecutionTimeout) {
+		workflow.WorkflowExecutionTimeout = timestamp.DurationPtr(time.Second * time.Duration(c.Int(FlagWorkflowExecutionTimeout)))
+	}
+	if c.IsSet(FlagWorkflowRunTimeout) {
+		workflow.WorkflowRunTimeout = timestamp.DurationPtr(time.Second * time.Duration(c.Int(FlagWorkflowRunTimeout)))
+	}
+	if c.IsSet(FlagWorkflowTaskTimeout) {
+		workflow.WorkflowTaskTimeout = timestamp.DurationPtr(time.Second * time.Duration(c.Int(FlagWorkflowTaskTimeout)))
+	}
+	if c.IsSet(FlagInput) || c.IsSet(FlagInputFile) {
+		inputs, err := processJSONInput(c)
+		if err != nil {
+			return err
+		}
+		workflow.Input = inputs
+	}
+	return nil
+}
+
+func patchSchedulePolicies(c *cli.Context, sched *schedpb.Schedule) error {
+	if sched.Policies == nil {
+		sched.Policies = &schedpb.SchedulePolicies{}
+	}
+	patch, err := buildSchedulePolicies(c)
+	if err != nil {
+		return err
+	}
+	if c.IsSet(FlagOverlapPolicy) {
+		sched.Policies.OverlapPolicy = patch.OverlapPolicy
+	}
+	if c.IsSet(FlagCatchupWindow) {
+		sched.Policies.CatchupWindow = patch.CatchupWindow
+	}
+	if c.IsSet(FlagPauseOnFailure) {
+		sched.Policies.PauseOnFailure = patch.PauseOnFailure
+	}
+	return nil
+}
+
+func patchScheduleState(c *cli.Context, sched *schedpb.Schedule) {
+	if sched.State == nil {
+		sched.State = &schedpb.ScheduleState{}
+	}
+	if c.IsSet(FlagNotes) {
+		sched.State.Notes = c.String(FlagNotes)
+	}
+	if c.IsSet(FlagPause) {
+		sched.State.Paused = c.Bool(FlagPause)
+	}
+	if c.IsSet(FlagRemainingActions) {
+		sched.State.LimitedActions = true
+		sched.State.RemainingActions = int64(c.Int(FlagRemainingActions))
+	}
+}
+
+func anyFlagSet(c *cli.Context, names []string) bool {
+	for _, name := range names {
+		if c.IsSet(name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,402 @@
+// The MIT License
+//
+// Copyright (c) 2022 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cli
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/dgryski/go-farm"
+	"github.com/temporalio/tctl-kit/pkg/color"
+	"github.com/temporalio/tctl-kit/pkg/output"
+	"github.com/urfave/cli/v2"
+	schedpb "go.temporal.io/api/schedule/v1"
+	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/server/common/primitives/timestamp"
+	"go.temporal.io/server/service/worker/scheduler"
+)
+
+// The evaluation below (nextTime, rawNextTime, excluded, addScheduleJitter and the previewCalendar
+// iteration) is copied from CompiledSpec.getNextTime, CompiledSpec.addJitter and compiledCalendar.next
+// in go.temporal.io/server/service/worker/scheduler at v1.18.1-0.20230217005328-b313b7f58641, which
+// does not export them. Parsing and canonicalization are done by the server package itself.
+// TestPreviewSchedule_MatchesServer holds times produced by that server version; update the copy and
+// the test together when bumping the server dependency.
+
+const previewMaxCalendarYear = 2100
+
+// schedulePreviewTime is a time a schedule will take an action at
+type schedulePreviewTime struct {
+	// NominalTime is the time matched by the spec, in the time zone of the schedule
+	NominalTime string
+	// ActionTime is the nominal time with the jitter the server will apply
+	ActionTime string
+	// JitterWindow is the latest time jitter may delay the action to
+	JitterWindow string
+	UTC          time.Time
+}
+
+type previewCalendar struct {
+	tz                                                    *time.Location
+	year, month, dayOfMonth, dayOfWeek, hour, minute, sec func(int) bool
+}
+
+type previewSpec struct {
+	spec      *schedpb.ScheduleSpec
+	tz        *time.Location
+	calendars []*previewCalendar
+	excludes  []*previewCalendar
+}
+
+// PreviewSchedule computes the next action times of a schedule spec locally
+func PreviewSchedule(c *cli.Context) error {
+	var spec *schedpb.ScheduleSpec
+	if c.IsSet(FlagScheduleID) {
+		frontendClient, namespace, scheduleID, err := scheduleBaseArgs(c)
+		if err != nil {
+			return err
+		}
+		ctx, cancel := newContext(c)
+		defer cancel()
+		resp, err := frontendClient.DescribeSchedule(ctx, &workflowservice.DescribeScheduleRequest{
+			Namespace:  namespace,
+			ScheduleId: scheduleID,
+		})
+		if err != nil {
+			return fmt.Errorf("unable to describe schedule: %w", err)
+		}
+		spec = resp.GetSchedule().GetSpec()
+	} else {
+		var err error
+		if spec, err = buildScheduleSpec(c); err != nil {
+			return err
+		}
+	}
+
+	preview, err := newPreviewSpec(spec)
+	if err != nil {
+		return err
+	}
+	times := preview.nextTimes(time.Now(), c.Int(FlagCount))
+
+	items := make([]interface{}, len(times))
+	for i, t := range times {
+		items[i] = t
+	}
+	err = output.PrintItems(c, items, &output.PrintOptions{
+		Fields:     []string{"NominalTime", "ActionTime", "JitterWindow"},
+		FieldsLong: []string{"UTC"},
+	})
+	if err != nil {
+		return err
+	}
+	if len(times) < c.Int(FlagCount) {
+		fmt.Println(color.Yellow(c, "The schedule takes no actions after %d more times", len(times)))
+	}
+	return nil
+}
+
+func newPreviewSpec(spec *schedpb.ScheduleSpec) (*previewSpec, error) {
+	if spec == nil {
+		spec = &schedpb.ScheduleSpec{}
+	}
+	compiled, err := scheduler.NewCompiledSpec(spec)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule spec: %w", err)
+	}
+	spec = compiled.CanonicalForm()
+
+	var tz *time.Location
+	if spec.TimezoneData != nil {
+		tz, err = time.LoadLocationFromTZData(spec.TimezoneName, spec.TimezoneData)
+	} else {
+		tz, err = time.LoadLocation(spec.TimezoneName)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to load time zone: %w", err)
+	}
+
+	preview := &previewSpec{spec: spec, tz: tz}
+	for _, cal := range spec.StructuredCalendar {
+		preview.calendars = append(preview.calendars, newPreviewCalendar(cal, tz))
+	}
+	for _, cal := range spec.ExcludeStructuredCalendar {
+		preview.excludes = append(preview.excludes, newPreviewCalendar(cal, tz))
+	}
+	return preview, nil
+}
+
+// nextTimes returns up to count action times after the given time
+func (ps *previewSpec) nextTimes(after time.Time, count int) []*schedulePreviewTime {
+	var times []*schedulePreviewTime
+	for len(times) < count {
+		nominal, maxJitter := ps.nextTime(after)
+		if nominal.IsZero() {
+			break
+		}
+		times = append(times, &schedulePreviewTime{
+			NominalTime:  formatPreviewTime(nominal, ps.tz),
+			ActionTime:   formatPreviewTime(addScheduleJitter(nominal, maxJitter), ps.tz),
+			JitterWindow: formatPreviewTime(nominal.Add(maxJitter), ps.tz),
+			UTC:          nominal,
+		})
+		after = nominal
+	}
+	return times
+}
+
+func formatPreviewTime(t time.Time, tz *time.Location) string {
+	return t.In(tz).Format("2006-01-02 15:04:05 MST")
+}
+
+// nextTime returns the first nominal time after the given time that is not excluded,
+// along with the maximum jitter for it
+func (ps *previewSpec) nextTime(after time.Time) (time.Time, time.Duration) {
+	if ps.spec.StartTime != nil && after.Before(*ps.spec.StartTime) {
+		after = ps.spec.StartTime.Add(-time.Second)
+	}
+
+	var nominal time.Time
+	for {
+		nominal = ps.rawNextTime(after)
+		if nominal.IsZero() || (ps.spec.EndTime != nil && nominal.After(*ps.spec.EndTime)) {
+			return time.Time{}, 0
+		}
+		if !ps.excluded(nominal) {
+			break
+		}
+		after = nominal
+	}
+
+	// jitter never pushes an action past the following nominal time
+	maxJitter := timestamp.DurationValue(ps.spec.Jitter)
+	if following := ps.rawNextTime(nominal); !following.IsZero() && following.Sub(nominal) < maxJitter {
+		maxJitter = following.Sub(nominal)
+	}
+	if maxJitter < 0 {
+		maxJitter = 0
+	}
+	return nominal, maxJitter
+}
+
+func (ps *previewSpec) rawNextTime(after time.Time) time.Time {
+	var minTimestamp int64 = math.MaxInt64
+	for _, cal := range ps.calendars {
+		if next := cal.next(after); !next.IsZero() && next.Unix() < minTimestamp {
+			minTimestamp = next.Unix()
+		}
+	}
+
+	ts := after.Unix()
+	for _, iv := range ps.spec.Interval {
+		interval := int64(timestamp.DurationValue(iv.Interval) / time.Second)
+		if interval < 1 {
+			interval = 1
+		}
+		phase := int64(timestamp.DurationValue(iv.Phase) / time.Second)
+		if phase < 0 {
+			phase = 0
+		}
+		if next := (((ts-phase)/interval)+1)*interval + phase; next < minTimestamp {
+			minTimestamp = next
+		}
+	}
+
+	if minTimestamp == math.MaxInt64 {
+		return time.Time{}
+	}
+	return time.Unix(minTimestamp, 0).UTC()
+}
+
+func (ps *previewSpec) excluded(nominal time.Time) bool {
+	for _, cal := range ps.excludes {
+		if cal.matches(nominal) {
+			return true
+		}
+	}
+	return false
+}
+
+// addScheduleJitter adds the jitter the server derives from a hash of the nominal time
+func addScheduleJitter(nominal time.Time, maxJitter time.Duration) time.Time {
+	bin, err := nominal.MarshalBinary()
+	if err != nil {
+		return nominal
+	}
+	fp := uint64(farm.Fingerprint32(bin))
+	ms := uint64(maxJitter.Milliseconds())
+	if ms > math.MaxUint32 {
+		ms = math.MaxUint32
+	}
+	return nominal.Add(time.Duration((fp*ms)>>32) * time.Millisecond)
+}
+
+func newPreviewCalendar(cal *schedpb.StructuredCalendarSpec, tz *time.Location) *previewCalendar {
+	return &previewCalendar{
+		tz:         tz,
+		year:       previewYearMatcher(cal.Year),
+		month:      previewBitMatcher(cal.Month),
+		dayOfMonth: previewBitMatcher(cal.DayOfMonth),
+		dayOfWeek:  previewBitMatcher(cal.DayOfWeek),
+		hour:       previewBitMatcher(cal.Hour),
+		minute:     previewBitMatcher(cal.Minute),
+		sec:        previewBitMatcher(cal.Second),
+	}
+}
+
+func (pc *previewCalendar) matches(ts time.Time) bool {
+	ts = ts.In(pc.tz)
+	y, mo, d := ts.Date()
+	h, m, s := ts.Clock()
+	return pc.year(y) && pc.month(int(mo)) && pc.dayOfMonth(d) && pc.dayOfWeek(int(ts.Weekday())) &&
+		pc.hour(h) && pc.minute(m) && pc.sec(s)
+}
+
+// next returns the earliest matching time after ts, with one second resolution. Times skipped
+// by a DST transition never match, and times repeated by one match twice.
+func (pc *previewCalendar) next(ts time.Time) time.Time {
+	ts = ts.In(pc.tz)
+	y, mo, d := ts.Date()
+	h, m, s := ts.Clock()
+	dstOffset := time.Duration(0)
+	if ts.Add(-time.Hour).Hour() == h {
+		// in the second copy of an hour repeated by DST
+		dstOffset = time.Hour
+	}
+	s++
+
+Outer:
+	for {
+		if s >= 60 {
+			m, s = m+1, 0
+		}
+		if m >= 60 {
+			prev := time.Date(y, mo, d, h, 0, 0, 0, pc.tz)
+			h, m = h+1, 0
+			next := time.Date(y, mo, d, h, 0, 0, 0, pc.tz)
+			// moving to the next hour took two hours, so an hour repeated by DST was skipped
+			if dstOffset == 0 && next.Sub(prev) > time.Hour {
+				h = h - 1
+				dstOffset = time.Hour
+			} else {
+				dstOffset = 0
+			}
+		}
+		if h >= 24 {
+			d, h = d+1, 0
+		}
+		if d > daysIn(mo, y) {
+			mo, d = mo+1, 1
+		}
+		if mo > time.December {
+			y, mo = y+1, time.January
+		}
+		if y > previewMaxCalendarYear {
+			break Outer
+		}
+		if !pc.year(y) {
+			y, mo, d, h, m, s = y+1, time.January, 1, 0, 0, 0
+			dstOffset = 0
+			continue Outer
+		}
+		for !pc.month(int(mo)) {
+			mo, d, h, m, s = mo+1, 1, 0, 0, 0
+			dstOffset = 0
+			if mo > time.December {
+				continue Outer
+			}
+		}
+		for !pc.dayOfMonth(d) || !pc.dayOfWeek(int(time.Date(y, mo, d, h, m, s, 0, pc.tz).Weekday())) {
+			d, h, m, s = d+1, 0, 0, 0
+			dstOffset = 0
+			if d > daysIn(mo, y) {
+				continue Outer
+			}
+		}
+		for !pc.hour(h) {
+			h, m, s = h+1, 0, 0
+			dstOffset = 0
+			if h >= 24 {
+				continue Outer
+			}
+		}
+		for !pc.minute(m) {
+			m, s = m+1, 0
+			if m >= 60 {
+				continue Outer
+			}
+		}
+		for !pc.sec(s) {
+			s = s + 1
+			if s >= 60 {
+				continue Outer
+			}
+		}
+		nextTs := time.Date(y, mo, d, h, m, s, 0, pc.tz)
+		// the time does not exist because DST skipped over it
+		if nextTs.Hour() != h {
+			h, m, s = h+1, 0, 0
+			continue Outer
+		}
+		return nextTs.Add(dstOffset)
+	}
+	return time.Time{}
+}
+
+func previewBitMatcher(ranges []*schedpb.Range) func(int) bool {
+	var bits uint64
+	iterateScheduleRanges(ranges, func(i int) { bits |= 1 << i })
+	return func(v int) bool { return (1<<v)&bits != 0 }
+}
+
+func previewYearMatcher(ranges []*schedpb.Range) func(int) bool {
+	if len(ranges) == 0 {
+		// all years are represented as an empty range list
+		return func(int) bool { return true }
+	}
+	years := make(map[int]bool)
+	iterateScheduleRanges(ranges, func(i int) { years[i] = true })
+	return func(v int) bool { return years[v] }
+}
+
+func iterateScheduleRanges(ranges []*schedpb.Range, f func(i int)) {
+	for _, r := range ranges {
+		start, end, step := int(r.GetStart()), int(r.GetEnd()), int(r.GetStep())
+		if step == 0 {
+			step = 1
+		}
+		if end < start {
+			end = start
+		}
+		for ; start <= end; start += step {
+			f(start)
+		}
+	}
+}
+
+func daysIn(m time.Month, y int) int {
+	return time.Date(y, m+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
@@ -0,0 +1,198 @@
+// The MIT License
+//
+// Copyright (c) 2022 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	schedpb "go.temporal.io/api/schedule/v1"
+	"go.temporal.io/server/common/primitives/timestamp"
+)
+
+var (
+	weekdayNames = []string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"}
+	monthNames   = []string{"", "January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"}
+)
+
+// describeScheduleSpec renders a canonical schedule spec in plain English, one line per calendar or interval,
+// e.g. "every Friday at 17:05 America/New_York"
+func describeScheduleSpec(spec *schedpb.ScheduleSpec) []string {
+	tz := spec.GetTimezoneName()
+	if tz == "" {
+		tz = "UTC"
+	}
+
+	var lines []string
+	for _, cal := range spec.GetStructuredCalendar() {
+		lines = append(lines, describeStructuredCalendar(cal)+" "+tz)
+	}
+	for _, interval := range spec.GetInterval() {
+		line := "every " + formatDuration(timestamp.DurationValue(interval.GetInterval()))
+		if phase := timestamp.DurationValue(interval.GetPhase()); phase > 0 {
+			line += " offset by " + formatDuration(phase)
+		}
+		lines = append(lines, line)
+	}
+	for _, cal := range spec.GetExcludeStructuredCalendar() {
+		lines = append(lines, "except "+describeStructuredCalendar(cal)+" "+tz)
+	}
+	if spec.GetStartTime() != nil {
+		lines = append(lines, "starting "+spec.GetStartTime().UTC().Format(time.RFC3339))
+	}
+	if spec.GetEndTime() != nil {
+		lines = append(lines, "ending "+spec.GetEndTime().UTC().Format(time.RFC3339))
+	}
+	if jitter := timestamp.DurationValue(spec.GetJitter()); jitter > 0 {
+		lines = append(lines, "with up to "+formatDuration(jitter)+" jitter")
+	}
+	return lines
+}
+
+func describeStructuredCalendar(cal *schedpb.StructuredCalendarSpec) string {
+	text := describeCalendarDays(cal)
+	if timeOfDay := describeCalendarTime(cal); strings.HasPrefix(timeOfDay, "at ") {
+		text += " " + timeOfDay
+	} else {
+		text += ", " + timeOfDay
+	}
+	if !isFullCalendarRange(cal.GetMonth(), 1, 12) {
+		text += " in " + describeCalendarRanges(cal.GetMonth(), func(v int32) string { return calendarName(monthNames, v) })
+	}
+	if len(cal.GetYear()) > 0 {
+		text += " in " + describeCalendarRanges(cal.GetYear(), calendarNumber)
+	}
+	if cal.GetComment() != "" {
+		text += " (" + cal.GetComment() + ")"
+	}
+	return text
+}
+
+func describeCalendarDays(cal *schedpb.StructuredCalendarSpec) string {
+	allDaysOfWeek := isFullCalendarRange(cal.GetDayOfWeek(), 0, 6)
+	allDaysOfMonth := isFullCalendarRange(cal.GetDayOfMonth(), 1, 31)
+	weekdays := describeCalendarRanges(cal.GetDayOfWeek(), func(v int32) string { return calendarName(weekdayNames, v%7) })
+	daysOfMonth := "day " + describeCalendarRanges(cal.GetDayOfMonth(), calendarNumber)
+	if !isSingleCalendarValue(cal.GetDayOfMonth()) {
+		daysOfMonth = "days " + describeCalendarRanges(cal.GetDayOfMonth(), calendarNumber)
+	}
+
+	switch {
+	case allDaysOfWeek && allDaysOfMonth:
+		return "every day"
+	case allDaysOfMonth:
+		return "every " + weekdays
+	case allDaysOfWeek:
+		return "on " + daysOfMonth + " of the month"
+	default:
+		return "on " + daysOfMonth + " of the month if it is a " + weekdays
+	}
+}
+
+func describeCalendarTime(cal *schedpb.StructuredCalendarSpec) string {
+	second, minute, hour := cal.GetSecond(), cal.GetMinute(), cal.GetHour()
+	if isSingleCalendarValue(second) && isSingleCalendarValue(minute) && isSingleCalendarValue(hour) {
+		text := fmt.Sprintf("at %02d:%02d", hour[0].GetStart(), minute[0].GetStart())
+		if second[0].GetStart() != 0 {
+			text += fmt.Sprintf(":%02d", second[0].GetStart())
+		}
+		return text
+	}
+
+	var parts []string
+	if !(isSingleCalendarValue(second) && second[0].GetStart() == 0) {
+		parts = append(parts, describeCalendarUnit(second, 59, "second"))
+	}
+	parts = append(parts, describeCalendarUnit(minute, 59, "minute"))
+	if !isFullCalendarRange(hour, 0, 23) || calendarStep(hour) > 1 {
+		parts = append(parts, describeCalendarUnit(hour, 23, "hour"))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// describeCalendarUnit describes a time of day field, e.g. "every minute", "every 15 minutes" or "at minute 5"
+func describeCalendarUnit(ranges []*schedpb.Range, max int32, unit string) string {
+	if len(ranges) == 0 || (len(ranges) == 1 && ranges[0].GetStart() == 0 && calendarRangeEnd(ranges[0]) >= max) {
+		if step := calendarStep(ranges); step > 1 {
+			return fmt.Sprintf("every %d %ss", step, unit)
+		}
+		return "every " + unit
+	}
+	return "at " + unit + " " + describeCalendarRanges(ranges, calendarNumber)
+}
+
+func describeCalendarRanges(ranges []*schedpb.Range, name func(int32) string) string {
+	var parts []string
+	for _, r := range ranges {
+		end := calendarRangeEnd(r)
+		switch {
+		case end == r.GetStart():
+			parts = append(parts, name(r.GetStart()))
+		case r.GetStep() > 1:
+			parts = append(parts, fmt.Sprintf("%s to %s every %d", name(r.GetStart()), name(end), r.GetStep()))
+		default:
+			parts = append(parts, name(r.GetStart())+" to "+name(end))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func isFullCalendarRange(ranges []*schedpb.Range, min int32, max int32) bool {
+	if len(ranges) == 0 {
+		return true
+	}
+	return len(ranges) == 1 && ranges[0].GetStart() <= min && calendarRangeEnd(ranges[0]) >= max && ranges[0].GetStep() <= 1
+}
+
+func isSingleCalendarValue(ranges []*schedpb.Range) bool {
+	return len(ranges) == 1 && calendarRangeEnd(ranges[0]) == ranges[0].GetStart()
+}
+
+func calendarStep(ranges []*schedpb.Range) int32 {
+	if len(ranges) == 1 {
+		return ranges[0].GetStep()
+	}
+	return 1
+}
+
+// calendarRangeEnd returns the last value of a range. Canonical ranges leave End unset for a single value.
+func calendarRangeEnd(r *schedpb.Range) int32 {
+	if r.GetEnd() < r.GetStart() {
+		return r.GetStart()
+	}
+	return r.GetEnd()
+}
+
+func calendarName(names []string, v int32) string {
+	if v >= 0 && int(v) < len(names) && names[v] != "" {
+		return names[v]
+	}
+	return calendarNumber(v)
+}
+
+func calendarNumber(v int32) string {
+	return fmt.Sprintf("%d", v)
+}
@@ -0,0 +1,687 @@
+// The MIT License
+//
+// Copyright (c) 2022 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cli
+
+import (
+	"context"
+	"path/filepath"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/mock"
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	historypb "go.temporal.io/api/history/v1"
+	schedpb "go.temporal.io/api/schedule/v1"
+	"go.temporal.io/api/serviceerror"
+	taskqueuepb "go.temporal.io/api/taskqueue/v1"
+	workflowpb "go.temporal.io/api/workflow/v1"
+	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/server/common/primitives/timestamp"
+	"go.temporal.io/server/service/worker/scheduler"
+)
+
+const testScheduleFile = `id: daily-report
+spec:
+  cron:
+    - "30 2 * * 5"
+  intervals:
+    - 90m/13m
+  timeZone: America/New_York
+action:
+  workflowId: report
+  workflowType: ReportWorkflow
+  taskQueue: reports
+  input:
+    - region: us-east
+      limit: 10
+  runTimeout: 1h
+policies:
+  overlapPolicy: BufferOne
+  catchupWindow: 10m
+state:
+  notes: managed from git
+  remainingActions: 5
+memo:
+  team: data
+`
+
+// canonicalScheduleFromFile returns the schedule as the server keeps it after creating it from the file
+func (s *cliAppSuite) canonicalScheduleFromFile(path string) *schedpb.Schedule {
+	file, err := readScheduleFile(path)
+	s.NoError(err)
+	sched, _, _, err := file.toSchedule()
+	s.NoError(err)
+	compiled, err := scheduler.NewCompiledSpec(sched.Spec)
+	s.NoError(err)
+	sched.Spec = compiled.CanonicalForm()
+	return sched
+}
+
+func (s *cliAppSuite) TestApplySchedule_Create() {
+	path := s.writeTempFile("schedule.yaml", testScheduleFile)
+	s.frontendClient.EXPECT().DescribeSchedule(gomock.Any(), gomock.Any()).Return(nil, serviceerror.NewNotFound("schedule not found"))
+	s.frontendClient.EXPECT().CreateSchedule(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, req *workflowservice.CreateScheduleRequest, _ ...interface{}) (*workflowservice.CreateScheduleResponse, error) {
+			s.Equal("daily-report", req.GetScheduleId())
+			s.Equal([]string{"30 2 * * 5"}, req.GetSchedule().GetSpec().GetCronString())
+			s.Equal("America/New_York", req.GetSchedule().GetSpec().GetTimezoneName())
+			workflow := req.GetSchedule().GetAction().GetStartWorkflow()
+			s.Equal("ReportWorkflow", workflow.GetWorkflowType().GetName())
+			s.Equal(`{"limit":10,"region":"us-east"}`, string(workflow.GetInput().GetPayloads()[0].GetData()))
+			s.True(req.GetSchedule().GetState().GetLimitedActions())
+			s.Equal(int64(5), req.GetSchedule().GetState().GetRemainingActions())
+			s.Contains(req.GetMemo().GetFields(), "team")
+			return &workflowservice.CreateScheduleResponse{}, nil
+		})
+
+	err := s.app.Run([]string{"", "--namespace", cliTestNamespace, "schedule", "apply", "-f", path})
+	s.Nil(err)
+}
+
+func (s *cliAppSuite) TestApplySchedule_UpToDate() {
+	path := s.writeTempFile("schedule.yaml", testScheduleFile)
+	sched := s.canonicalScheduleFromFile(path)
+	// actions taken since the schedule was applied do not count as a change
+	sched.State.RemainingActions = 3
+	memo, err := encodeMemo(map[string]interface{}{"team": "data"})
+	s.NoError(err)
+	s.frontendClient.EXPECT().DescribeSchedule(gomock.Any(), gomock.Any()).Return(&workflowservice.DescribeScheduleResponse{Schedule: sched, Memo: memo}, nil)
+
+	err = s.app.Run([]string{"", "--namespace", cliTestNamespace, "schedule", "apply", "-f", path})
+	s.Nil(err)
+}
+
+func (s *cliAppSuite) TestApplySchedule_Update() {
+	path := s.writeTempFile("schedule.yaml", testScheduleFile)
+	sched := s.canonicalScheduleFromFile(path)
+	sched.Policies.CatchupWindow = nil
+	s.frontendClient.EXPECT().DescribeSchedule(gomock.Any(), gomock.Any()).
+		Return(&workflowservice.DescribeScheduleResponse{Schedule: sched, ConflictToken: []byte("token")}, nil).Times(2)
+
+	err := s.app.Run([]string{"", "--namespace", cliTestNamespace, "schedule", "apply", "-f", path, "--dry-run"})
+	s.Nil(err)
+
+	s.frontendClient.EXPECT().UpdateSchedule(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, req *workflowservice.UpdateScheduleRequest, _ ...interface{}) (*workflowservice.UpdateScheduleResponse, error) {
+			s.Equal([]byte("token"), req.GetConflictToken())
+			s.NotNil(req.GetSchedule().GetPolicies().GetCatchupWindow())
+			return &workflowservice.UpdateScheduleResponse{}, nil
+		})
+	err = s.app.Run([]string{"", "--namespace", cliTestNamespace, "schedule", "apply", "-f", path})
+	s.Nil(err)
+}
+
+func (s *cliAppSuite) TestApplySchedule_InvalidFile() {
+	path := s.writeTempFile("schedule.yaml", "id: daily-report\nspec:\n  crons: [\"@daily\"]\n")
+	errorCode := s.RunWithExitCode([]string{"", "--namespace", cliTestNamespace, "schedule", "apply", "-f", path})
+	s.Equal(1, errorCode)
+}
+
+func (s *cliAppSuite) TestExportSchedule() {
+	sched := s.canonicalScheduleFromFile(s.writeTempFile("schedule.yaml", testScheduleFile))
+	s.frontendClient.EXPECT().DescribeSchedule(gomock.Any(), gomock.Any()).Return(&workflowservice.DescribeScheduleResponse{Schedule: sched}, nil)
+
+	exported := filepath.Join(s.T().TempDir(), "exported.yaml")
+	err := s.app.Run([]string{"", "--namespace", cliTestNamespace, "schedule", "export", "--schedule-id", "daily-report", "--output-filename", exported})
+	s.Nil(err)
+
+	// applying the export leaves the schedule as it is
+	s.Equal(sched, s.canonicalScheduleFromFile(exported))
+	file, err := readScheduleFile(exported)
+	s.NoError(err)
+	s.Equal([]string{"1h30m/13m"}, file.Spec.Intervals)
+	s.Equal("1h", file.Action.RunTimeout)
+	s.Equal("BufferOne", file.Policies.OverlapPolicy)
+}
+
+func (s *cliAppSuite) previewUTC(spec *schedpb.ScheduleSpec, after time.Time, count int) []time.Time {
+	preview, err := newPreviewSpec(spec)
+	s.NoError(err)
+	var times []time.Time
+	for _, t := range preview.nextTimes(after, count) {
+		times = append(times, t.UTC)
+	}
+	return times
+}
+
+func (s *cliAppSuite) TestPreviewSchedule_DST() {
+	newYork, err := time.LoadLocation("America/New_York")
+	s.NoError(err)
+
+	// 2:30 does not exist on the day clocks move forward
+	times := s.previewUTC(&schedpb.ScheduleSpec{CronString: []string{"30 2 * * *"}, TimezoneName: "America/New_York"},
+		time.Date(2023, 3, 11, 0, 0, 0, 0, newYork), 2)
+	s.Equal([]time.Time{
+		time.Date(2023, 3, 11, 2, 30, 0, 0, newYork).UTC(),
+		time.Date(2023, 3, 13, 2, 30, 0, 0, newYork).UTC(),
+	}, times)
+
+	// 1:30 happens twice on the day clocks move back
+	times = s.previewUTC(&schedpb.ScheduleSpec{CronString: []string{"30 1 * * *"}, TimezoneName: "America/New_York"},
+		time.Date(2023, 11, 5, 0, 0, 0, 0, newYork), 3)
+	s.Equal([]time.Time{
+		time.Date(2023, 11, 5, 5, 30, 0, 0, time.UTC),
+		time.Date(2023, 11, 5, 6, 30, 0, 0, time.UTC),
+		time.Date(2023, 11, 6, 6, 30, 0, 0, time.UTC),
+	}, times)
+}
+
+func (s *cliAppSuite) TestPreviewSchedule_IntervalAndExclude() {
+	interval, err := buildIntervalSpec("90m/13m")
+	s.NoError(err)
+	times := s.previewUTC(&schedpb.ScheduleSpec{Interval: []*schedpb.IntervalSpec{interval}},
+		time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), 3)
+	s.Equal([]time.Time{
+		time.Date(2023, 1, 1, 0, 13, 0, 0, time.UTC),
+		time.Date(2023, 1, 1, 1, 43, 0, 0, time.UTC),
+		time.Date(2023, 1, 1, 3, 13, 0, 0, time.UTC),
+	}, times)
+
+	times = s.previewUTC(&schedpb.ScheduleSpec{
+		CronString:      []string{"@daily"},
+		ExcludeCalendar: []*schedpb.CalendarSpec{{DayOfWeek: "Sat,Sun"}},
+		EndTime:         timestamp.TimePtr(time.Date(2023, 1, 10, 0, 0, 0, 0, time.UTC)),
+	}, time.Date(2023, 1, 5, 12, 0, 0, 0, time.UTC), 10)
+	s.Equal([]time.Time{
+		time.Date(2023, 1, 6, 0, 0, 0, 0, time.UTC),
+		time.Date(2023, 1, 9, 0, 0, 0, 0, time.UTC),
+		time.Date(2023, 1, 10, 0, 0, 0, 0, time.UTC),
+	}, times)
+}
+
+func (s *cliAppSuite) TestPreviewSchedule_Jitter() {
+	preview, err := newPreviewSpec(&schedpb.ScheduleSpec{CronString: []string{"@hourly"}, Jitter: timestamp.DurationPtr(2 * time.Hour)})
+	s.NoError(err)
+	nominal, maxJitter := preview.nextTime(time.Date(2023, 1, 1, 0, 30, 0, 0, time.UTC))
+	s.Equal(time.Date(2023, 1, 1, 1, 0, 0, 0, time.UTC), nominal)
+	// jitter is capped by the following action time
+	s.Equal(time.Hour, maxJitter)
+	jittered := addScheduleJitter(nominal, maxJitter)
+	s.False(jittered.Before(nominal))
+	s.True(jittered.Before(nominal.Add(maxJitter)))
+}
+
+// TestPreviewSchedule_MatchesServer checks the preview against times produced by the server's
+// CompiledSpec.getNextTime at the server version in go.mod. Regenerate the expected times from the
+// server when bumping that dependency; a mismatch means the copied evaluation has drifted.
+func (s *cliAppSuite) TestPreviewSchedule_MatchesServer() {
+	start := time.Date(2023, 3, 11, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2023, 3, 20, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		spec     *schedpb.ScheduleSpec
+		expected [][2]string // nominal and jittered times
+		ended    bool
+	}{
+		{
+			name: "time zone across DST",
+			spec: &schedpb.ScheduleSpec{CronString: []string{"30 2 * * *"}, TimezoneName: "America/New_York"},
+			expected: [][2]string{
+				{"2023-03-11T07:30:00Z", "2023-03-11T07:30:00Z"},
+				{"2023-03-13T06:30:00Z", "2023-03-13T06:30:00Z"},
+				{"2023-03-14T06:30:00Z", "2023-03-14T06:30:00Z"},
+			},
+		},
+		{
+			name: "interval with phase and exclude",
+			spec: &schedpb.ScheduleSpec{
+				Interval:        []*schedpb.IntervalSpec{{Interval: timestamp.DurationPtr(90 * time.Minute), Phase: timestamp.DurationPtr(7 * time.Minute)}},
+				ExcludeCalendar: []*schedpb.CalendarSpec{{Second: "*", Minute: "*", Hour: "0-5"}},
+			},
+			expected: [][2]string{
+				{"2023-03-10T22:37:00Z", "2023-03-10T22:37:00Z"},
+				{"2023-03-11T06:07:00Z", "2023-03-11T06:07:00Z"},
+				{"2023-03-11T07:37:00Z", "2023-03-11T07:37:00Z"},
+			},
+		},
+		{
+			name: "jitter",
+			spec: &schedpb.ScheduleSpec{CronString: []string{"0 */6 * * MON-FRI"}, Jitter: timestamp.DurationPtr(2 * time.Hour)},
+			expected: [][2]string{
+				{"2023-03-13T00:00:00Z", "2023-03-13T00:45:13.298Z"},
+				{"2023-03-13T06:00:00Z", "2023-03-13T06:18:27.083Z"},
+				{"2023-03-13T12:00:00Z", "2023-03-13T13:45:25.347Z"},
+				{"2023-03-13T18:00:00Z", "2023-03-13T18:44:33.193Z"},
+			},
+		},
+		{
+			name: "calendar with start and end time",
+			spec: &schedpb.ScheduleSpec{
+				Calendar:  []*schedpb.CalendarSpec{{Second: "15", Minute: "*/20", Hour: "9", DayOfWeek: "SUN"}},
+				StartTime: &start,
+				EndTime:   &end,
+				Jitter:    timestamp.DurationPtr(30 * time.Minute),
+			},
+			expected: [][2]string{
+				{"2023-03-12T09:00:15Z", "2023-03-12T09:12:33.307Z"},
+				{"2023-03-12T09:20:15Z", "2023-03-12T09:23:03.696Z"},
+				{"2023-03-12T09:40:15Z", "2023-03-12T09:59:39.584Z"},
+				{"2023-03-19T09:00:15Z", "2023-03-19T09:05:47.97Z"},
+				{"2023-03-19T09:20:15Z", "2023-03-19T09:32:19.665Z"},
+				{"2023-03-19T09:40:15Z", "2023-03-19T10:08:24.977Z"},
+			},
+			ended: true,
+		},
+	}
+	for _, tt := range tests {
+		preview, err := newPreviewSpec(tt.spec)
+		s.NoError(err, tt.name)
+		var actual [][2]string
+		after := time.Date(2023, 3, 10, 22, 0, 0, 0, time.UTC)
+		for len(actual) < len(tt.expected) {
+			nominal, maxJitter := preview.nextTime(after)
+			if nominal.IsZero() {
+				break
+			}
+			actual = append(actual, [2]string{nominal.Format(time.RFC3339), addScheduleJitter(nominal, maxJitter).UTC().Format(time.RFC3339Nano)})
+			after = nominal
+		}
+		s.Equal(tt.expected, actual, tt.name)
+		if tt.ended {
+			nominal, _ := preview.nextTime(after)
+			s.True(nominal.IsZero(), tt.name)
+		}
+	}
+}
+
+func (s *cliAppSuite) TestPreviewSchedule() {
+	err := s.app.Run([]string{"", "--namespace", cliTestNamespace, "schedule", "preview", "--cron", "@daily", "--time-zone", "Europe/Berlin", "--jitter", "10m", "--count", "3"})
+	s.Nil(err)
+
+	s.frontendClient.EXPECT().DescribeSchedule(gomock.Any(), gomock.Any()).Return(&workflowservice.DescribeScheduleResponse{
+		Schedule: &schedpb.Schedule{Spec: &schedpb.ScheduleSpec{CronString: []string{"@weekly"}}},
+	}, nil)
+	err = s.app.Run([]string{"", "--namespace", cliTestNamespace, "schedule", "preview", "--schedule-id", "weekly-report"})
+	s.Nil(err)
+}
+
+func (s *cliAppSuite) TestUpdateSchedule_Patch() {
+	sched := s.canonicalScheduleFromFile(s.writeTempFile("schedule.yaml", testScheduleFile))
+	s.frontendClient.EXPECT().DescribeSchedule(gomock.Any(), gomock.Any()).
+		Return(&workflowservice.DescribeScheduleResponse{Schedule: sched, ConflictToken: []byte("token")}, nil)
+	s.frontendClient.EXPECT().UpdateSchedule(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, req *workflowservice.UpdateScheduleRequest, _ ...interface{}) (*workflowservice.UpdateScheduleResponse, error) {
+			s.Equal([]byte("token"), req.GetConflictToken())
+			spec := req.GetSchedule().GetSpec()
+			s.Equal("America/New_York", spec.GetTimezoneName())
+			s.Empty(spec.GetStructuredCalendar())
+			s.Len(spec.GetCalendar(), 1)
+			s.Equal("17", spec.GetCalendar()[0].GetHour())
+			s.Len(spec.GetInterval(), 1)
+			workflow := req.GetSchedule().GetAction().GetStartWorkflow()
+			s.Equal("reports-v2", workflow.GetTaskQueue().GetName())
+			s.Equal("ReportWorkflow", workflow.GetWorkflowType().GetName())
+			s.Len(workflow.GetInput().GetPayloads(), 1)
+			s.Equal(enumspb.SCHEDULE_OVERLAP_POLICY_SKIP, req.GetSchedule().GetPolicies().GetOverlapPolicy())
+			s.Equal("patched", req.GetSchedule().GetState().GetNotes())
+			s.True(req.GetSchedule().GetState().GetLimitedActions())
+			return &workflowservice.UpdateScheduleResponse{}, nil
+		})
+
+	err := s.app.Run([]string{"", "--namespace", cliTestNamespace, "schedule", "update", "--patch", "--schedule-id", "daily-report",
+		"--remove-calendar", `{"minute":"30","hour":"2","dayOfWeek":"5"}`,
+		"--calendar", `{"dayOfWeek":"Fri","hour":"17"}`,
+		"--task-queue", "reports-v2",
+		"--overlap-policy", "Skip",
+		"--notes", "patched",
+	})
+	s.Nil(err)
+}
+
+func (s *cliAppSuite) TestUpdateSchedule_PatchNoChanges() {
+	sched := s.canonicalScheduleFromFile(s.writeTempFile("schedule.yaml", testScheduleFile))
+	s.frontendClient.EXPECT().DescribeSchedule(gomock.Any(), gomock.Any()).
+		Return(&workflowservice.DescribeScheduleResponse{Schedule: sched}, nil).Times(2)
+
+	err := s.app.Run([]string{"", "--namespace", cliTestNamespace, "schedule", "update", "--patch", "--schedule-id", "daily-report",
+		"--task-queue", "reports", "--time-zone", "America/New_York"})
+	s.Nil(err)
+
+	errorCode := s.RunWithExitCode([]string{"", "--namespace", cliTestNamespace, "schedule", "update", "--patch", "--schedule-id", "daily-report",
+		"--remove-calendar", `{"hour":"3"}`})
+	s.Equal(1, errorCode)
+}
+
+func (s *cliAppSuite) TestUpdateSchedule_FullRequiresWorkflow() {
+	errorCode := s.RunWithExitCode([]string{"", "--namespace", cliTestNamespace, "schedule", "update", "--schedule-id", "daily-report", "--cron", "@daily"})
+	s.Equal(1, errorCode)
+}
+
+func (s *cliAppSuite) TestDiffScheduleFields() {
+	before := &scheduleFile{ID: "a", Spec: scheduleFileSpec{TimeZone: "UTC", Cron: []string{"@daily"}}}
+	after := &scheduleFile{ID: "a", Spec: scheduleFileSpec{Cron: []string{"@daily", "@hourly"}}}
+	changes, err := diffFields(before, after)
+	s.NoError(err)
+	s.Equal([]string{
+		`spec.cron[1]: (unset) -> "@hourly"`,
+		`spec.timeZone: "UTC" -> (unset)`,
+	}, changes)
+}
+
+func (s *cliAppSuite) TestDescribeScheduleSpec() {
+	interval, err := buildIntervalSpec("90m/13m")
+	s.NoError(err)
+	compiled, err := scheduler.NewCompiledSpec(&schedpb.ScheduleSpec{
+		CronString:   []string{"5 17 * * 5", "*/15 9-17 * * 1-5", "@monthly"},
+		Calendar:     []*schedpb.CalendarSpec{{Hour: "8", Month: "Jan,Jul", Comment: "twice a year"}},
+		Interval:     []*schedpb.IntervalSpec{interval},
+		Jitter:       timestamp.DurationPtr(time.Minute),
+		TimezoneName: "America/New_York",
+	})
+	s.NoError(err)
+	s.Equal([]string{
+		"every day at 08:00 in January, July (twice a year) America/New_York",
+		"every Friday at 17:05 America/New_York",
+		"every Monday to Friday, every 15 minutes, at hour 9 to 17 America/New_York",
+		"on day 1 of the month at 00:00 America/New_York",
+		"every 1h30m offset by 13m",
+		"with up to 1m jitter",
+	}, describeScheduleSpec(compiled.CanonicalForm()))
+}
+
+func (s *cliAppSuite) TestDescribeSchedule() {
+	sched := s.canonicalScheduleFromFile(s.writeTempFile("schedule.yaml", testScheduleFile))
+	now := time.Now()
+	first := &commonpb.WorkflowExecution{WorkflowId: "report-1", RunId: "run-1"}
+	second := &commonpb.WorkflowExecution{WorkflowId: "report-2", RunId: "run-2"}
+	var future []*time.Time
+	for i := 1; i <= 12; i++ {
+		future = append(future, timestamp.TimePtr(now.Add(time.Duration(i)*time.Hour)))
+	}
+	s.frontendClient.EXPECT().DescribeSchedule(gomock.Any(), gomock.Any()).Return(&workflowservice.DescribeScheduleResponse{
+		Schedule: sched,
+		Info: &schedpb.ScheduleInfo{
+			RecentActions: []*schedpb.ScheduleActionResult{
+				{ScheduleTime: timestamp.TimePtr(now.Add(-2 * time.Hour)), ActualTime: timestamp.TimePtr(now.Add(-2 * time.Hour)), StartWorkflowResult: first},
+				{ScheduleTime: timestamp.TimePtr(now.Add(-time.Hour)), ActualTime: timestamp.TimePtr(now.Add(-time.Hour)), StartWorkflowResult: second},
+			},
+			RunningWorkflows:  []*commonpb.WorkflowExecution{second},
+			FutureActionTimes: future,
+		},
+	}, nil)
+	s.frontendClient.EXPECT().DescribeWorkflowExecution(gomock.Any(), gomock.Any()).Return(nil, serviceerror.NewNotFound("past retention"))
+	s.frontendClient.EXPECT().DescribeWorkflowExecution(gomock.Any(), gomock.Any()).Return(&workflowservice.DescribeWorkflowExecutionResponse{
+		WorkflowExecutionInfo: &workflowpb.WorkflowExecutionInfo{Execution: second, Status: enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING},
+	}, nil)
+
+	err := s.app.Run([]string{"", "--namespace", cliTestNamespace, "schedule", "describe", "--schedule-id", "daily-report"})
+	s.Nil(err)
+}
+
+func (s *cliAppSuite) TestListSchedules_Filter() {
+	entry := func(id string, paused bool, workflowType string) *schedpb.ScheduleListEntry {
+		return &schedpb.ScheduleListEntry{
+			ScheduleId: id,
+			Info: &schedpb.ScheduleListInfo{
+				Paused:       paused,
+				WorkflowType: &commonpb.WorkflowType{Name: workflowType},
+			},
+		}
+	}
+	resp := &workflowservice.ListSchedulesResponse{Schedules: []*schedpb.ScheduleListEntry{
+		entry("a", true, "ReportWorkflow"),
+		entry("b", false, "ReportWorkflow"),
+		entry("c", true, "CleanupWorkflow"),
+	}}
+	s.frontendClient.EXPECT().ListSchedules(gomock.Any(), gomock.Any()).Return(resp, nil).Times(2)
+	err := s.app.Run([]string{"", "--namespace", cliTestNamespace, "schedule", "list", "--paused", "--workflow-type", "ReportWorkflow"})
+	s.Nil(err)
+	err = s.app.Run([]string{"", "--namespace", cliTestNamespace, "schedule", "list", "--paused=false"})
+	s.Nil(err)
+
+	// without Elasticsearch there is no schedule info to filter on
+	s.frontendClient.EXPECT().ListSchedules(gomock.Any(), gomock.Any()).Return(&workflowservice.ListSchedulesResponse{
+		Schedules: []*schedpb.ScheduleListEntry{{ScheduleId: "a"}},
+	}, nil)
+	errorCode := s.RunWithExitCode([]string{"", "--namespace", cliTestNamespace, "schedule", "list", "--paused"})
+	s.Equal(1, errorCode)
+}
+
+func (s *cliAppSuite) TestToggleSchedule_Bulk() {
+	entry := func(id string, paused bool) *schedpb.ScheduleListEntry {
+		return &schedpb.ScheduleListEntry{ScheduleId: id, Info: &schedpb.ScheduleListInfo{Paused: paused}}
+	}
+	s.frontendClient.EXPECT().ListSchedules(gomock.Any(), gomock.Any()).Return(&workflowservice.ListSchedulesResponse{
+		Schedules:     []*schedpb.ScheduleListEntry{entry("nightly-a", false), entry("hourly-b", false)},
+		NextPageToken: []byte("next"),
+	}, nil)
+	s.frontendClient.EXPECT().ListSchedules(gomock.Any(), gomock.Any()).Return(&workflowservice.ListSchedulesResponse{
+		Schedules: []*schedpb.ScheduleListEntry{entry("nightly-c", false), entry("nightly-d", true)},
+	}, nil)
+	var paused []string
+	s.frontendClient.EXPECT().PatchSchedule(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, req *workflowservice.PatchScheduleRequest, _ ...interface{}) (*workflowservice.PatchScheduleResponse, error) {
+			s.Equal("maintenance", req.GetPatch().GetPause())
+			paused = append(paused, req.GetScheduleId())
+			return &workflowservice.PatchScheduleResponse{}, nil
+		}).Times(2)
+
+	err := s.app.Run([]string{"", "--namespace", cliTestNamespace, "schedule", "toggle", "--pause", "--reason", "maintenance",
+		"--schedule-id-prefix", "nightly-", "--paused=false", "--concurrency", "1", "--yes"})
+	s.Nil(err)
+	s.Equal([]string{"nightly-a", "nightly-c"}, paused)
+}
+
+func (s *cliAppSuite) TestDeleteSchedule_BulkFailure() {
+	s.frontendClient.EXPECT().ListSchedules(gomock.Any(), gomock.Any()).Return(&workflowservice.ListSchedulesResponse{
+		Schedules: []*schedpb.ScheduleListEntry{{ScheduleId: "a"}, {ScheduleId: "b"}, {ScheduleId: "c"}},
+	}, nil)
+	s.frontendClient.EXPECT().DeleteSchedule(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, req *workflowservice.DeleteScheduleRequest, _ ...interface{}) (*workflowservice.DeleteScheduleResponse, error) {
+			switch req.GetScheduleId() {
+			case "a":
+				return nil, serviceerror.NewNotFound("already deleted")
+			case "b":
+				return nil, serviceerror.NewPermissionDenied("denied", "")
+			}
+			return &workflowservice.DeleteScheduleResponse{}, nil
+		}).Times(3)
+
+	errorCode := s.RunWithExitCode([]string{"", "--namespace", cliTestNamespace, "schedule", "delete", "--all", "--yes"})
+	s.Equal(1, errorCode)
+}
+
+func (s *cliAppSuite) TestTriggerSchedule_BulkRequiresTarget() {
+	errorCode := s.RunWithExitCode([]string{"", "--namespace", cliTestNamespace, "schedule", "trigger"})
+	s.Equal(1, errorCode)
+	errorCode = s.RunWithExitCode([]string{"", "--namespace", cliTestNamespace, "schedule", "trigger", "--schedule-id", "a", "--all"})
+	s.Equal(1, errorCode)
+}
+
+func (s *cliAppSuite) TestSplitBackfill() {
+	preview, err := newPreviewSpec(&schedpb.ScheduleSpec{CronString: []string{"@daily"}})
+	s.NoError(err)
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2023, 1, 3, 12, 0, 0, 0, time.UTC)
+
+	chunks := splitBackfill(preview, start, end, 24*time.Hour)
+	s.Len(chunks, 3)
+	var times []time.Time
+	for _, chunk := range chunks {
+		for _, t := range chunk.times {
+			times = append(times, t.UTC)
+		}
+	}
+	// the start of the range is excluded and its end included, like the server does
+	s.Equal([]time.Time{
+		time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+		time.Date(2023, 1, 3, 0, 0, 0, 0, time.UTC),
+	}, times)
+	s.Equal(end, chunks[2].end)
+
+	chunks = splitBackfill(preview, start, end, 0)
+	s.Len(chunks, 1)
+	s.Len(chunks[0].times, 2)
+}
+
+func (s *cliAppSuite) TestBackfillSchedule_DryRun() {
+	s.frontendClient.EXPECT().DescribeSchedule(gomock.Any(), gomock.Any()).Return(&workflowservice.DescribeScheduleResponse{
+		Schedule: &schedpb.Schedule{Spec: &schedpb.ScheduleSpec{CronString: []string{"@daily"}}},
+	}, nil)
+	err := s.app.Run([]string{"", "--namespace", cliTestNamespace, "schedule", "backfill", "--schedule-id", "daily-report",
+		"--start-time", "2023-01-01T00:00:00Z", "--end-time", "2023-01-10T00:00:00Z", "--chunk", "3d", "--dry-run"})
+	s.Nil(err)
+}
+
+func (s *cliAppSuite) TestBackfillSchedule_ChunkWait() {
+	origInterval := backfillPollInterval
+	backfillPollInterval = 0
+	defer func() { backfillPollInterval = origInterval }()
+
+	running := []*commonpb.WorkflowExecution{{WorkflowId: "report"}}
+	var actions int64
+	s.frontendClient.EXPECT().DescribeSchedule(gomock.Any(), gomock.Any()).Return(&workflowservice.DescribeScheduleResponse{
+		Schedule: &schedpb.Schedule{Spec: &schedpb.ScheduleSpec{CronString: []string{"@daily"}}},
+	}, nil)
+	// the workflow of a chunk shows as running on every other describe, so waiting takes more than one poll
+	s.frontendClient.EXPECT().DescribeSchedule(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *workflowservice.DescribeScheduleRequest, _ ...interface{}) (*workflowservice.DescribeScheduleResponse, error) {
+			info := &schedpb.ScheduleInfo{ActionCount: actions, RunningWorkflows: running}
+			if len(running) == 0 {
+				running = []*commonpb.WorkflowExecution{{WorkflowId: "report"}}
+			} else {
+				running = nil
+			}
+			return &workflowservice.DescribeScheduleResponse{Info: info}, nil
+		}).AnyTimes()
+	var chunkEnds []time.Time
+	s.frontendClient.EXPECT().PatchSchedule(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, req *workflowservice.PatchScheduleRequest, _ ...interface{}) (*workflowservice.PatchScheduleResponse, error) {
+			chunkEnds = append(chunkEnds, *req.GetPatch().GetBackfillRequest()[0].GetEndTime())
+			actions++
+			return &workflowservice.PatchScheduleResponse{}, nil
+		}).Times(2)
+
+	err := s.app.Run([]string{"", "--namespace", cliTestNamespace, "schedule", "backfill", "--schedule-id", "daily-report",
+		"--start-time", "2023-01-01T00:00:00Z", "--end-time", "2023-01-03T00:00:00Z", "--chunk", "1d", "--wait"})
+	s.Nil(err)
+	s.Equal([]time.Time{
+		time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+		time.Date(2023, 1, 3, 0, 0, 0, 0, time.UTC),
+	}, chunkEnds)
+}
+
+func (s *cliAppSuite) TestBackfillSchedule_WaitTimeout() {
+	origInterval := backfillPollInterval
+	backfillPollInterval = 0
+	defer func() { backfillPollInterval = origInterval }()
+
+	s.frontendClient.EXPECT().DescribeSchedule(gomock.Any(), gomock.Any()).Return(&workflowservice.DescribeScheduleResponse{
+		Schedule: &schedpb.Schedule{Spec: &schedpb.ScheduleSpec{CronString: []string{"@daily"}}},
+	}, nil)
+	s.frontendClient.EXPECT().DescribeSchedule(gomock.Any(), gomock.Any()).Return(&workflowservice.DescribeScheduleResponse{Info: &schedpb.ScheduleInfo{}}, nil)
+	// the only new action is a regular one, scheduled after the backfilled range, so the backfill never finishes
+	s.frontendClient.EXPECT().DescribeSchedule(gomock.Any(), gomock.Any()).Return(&workflowservice.DescribeScheduleResponse{Info: &schedpb.ScheduleInfo{
+		ActionCount: 1,
+		RecentActions: []*schedpb.ScheduleActionResult{{
+			ScheduleTime: timestamp.TimePtr(time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)),
+			ActualTime:   timestamp.TimePtr(time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)),
+		}},
+	}}, nil).MinTimes(1)
+	s.frontendClient.EXPECT().PatchSchedule(gomock.Any(), gomock.Any()).Return(&workflowservice.PatchScheduleResponse{}, nil)
+
+	errorCode := s.RunWithExitCode([]string{"", "--namespace", cliTestNamespace, "schedule", "backfill", "--schedule-id", "daily-report",
+		"--start-time", "2023-01-01T00:00:00Z", "--end-time", "2023-01-02T00:00:00Z", "--wait", "--wait-timeout", "10ms"})
+	s.Equal(1, errorCode)
+}
+
+func (s *cliAppSuite) expectCronWorkflowHistories() {
+	s.sdkClient.On("ListWorkflow", mock.Anything, mock.MatchedBy(func(req *workflowservice.ListWorkflowExecutionsRequest) bool {
+		return req.GetQuery() == "(WorkflowType = 'ReportWorkflow') AND ExecutionStatus = 'Running'"
+	})).Return(listWorkflowExecutionsResponse, nil).Once()
+	s.frontendClient.EXPECT().GetWorkflowExecutionHistory(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, req *workflowservice.GetWorkflowExecutionHistoryRequest, _ ...interface{}) (*workflowservice.GetWorkflowExecutionHistoryResponse, error) {
+			attrs := &historypb.WorkflowExecutionStartedEventAttributes{
+				WorkflowType:        &commonpb.WorkflowType{Name: "ReportWorkflow"},
+				TaskQueue:           &taskqueuepb.TaskQueue{Name: "reports"},
+				FirstExecutionRunId: "first-run-id",
+			}
+			if req.GetExecution().GetWorkflowId() == "test-list-open-workflow-id" {
+				attrs.CronSchedule = "0 2 * * *"
+			}
+			return &workflowservice.GetWorkflowExecutionHistoryResponse{History: &historypb.History{Events: []*historypb.HistoryEvent{{
+				EventType:  enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_STARTED,
+				Attributes: &historypb.HistoryEvent_WorkflowExecutionStartedEventAttributes{WorkflowExecutionStartedEventAttributes: attrs},
+			}}}}, nil
+		}).Times(2)
+}
+
+func (s *cliAppSuite) TestMigrateCronWorkflows() {
+	s.expectCronWorkflowHistories()
+	s.frontendClient.EXPECT().CreateSchedule(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, req *workflowservice.CreateScheduleRequest, _ ...interface{}) (*workflowservice.CreateScheduleResponse, error) {
+			s.Equal("test-list-open-workflow-id", req.GetScheduleId())
+			s.Equal([]string{"0 2 * * *"}, req.GetSchedule().GetSpec().GetCronString())
+			s.Equal("reports", req.GetSchedule().GetAction().GetStartWorkflow().GetTaskQueue().GetName())
+			return &workflowservice.CreateScheduleResponse{}, nil
+		})
+	s.frontendClient.EXPECT().DescribeSchedule(gomock.Any(), gomock.Any()).Return(&workflowservice.DescribeScheduleResponse{}, nil)
+	s.frontendClient.EXPECT().TerminateWorkflowExecution(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, req *workflowservice.TerminateWorkflowExecutionRequest, _ ...interface{}) (*workflowservice.TerminateWorkflowExecutionResponse, error) {
+			s.Equal("test-list-open-workflow-id", req.GetWorkflowExecution().GetWorkflowId())
+			// the current run of the cron chain, which may no longer be the listed run
+			s.Empty(req.GetWorkflowExecution().GetRunId())
+			s.Equal("first-run-id", req.GetFirstExecutionRunId())
+			return &workflowservice.TerminateWorkflowExecutionResponse{}, nil
+		})
+
+	err := s.app.Run([]string{"", "--namespace", cliTestNamespace, "schedule", "migrate-cron", "--query", "WorkflowType = 'ReportWorkflow'", "--terminate-old", "--yes"})
+	s.Nil(err)
+	s.sdkClient.AssertExpectations(s.T())
+}
+
+func (s *cliAppSuite) TestMigrateCronWorkflows_Rerun() {
+	// the schedule was created by an earlier run that failed to terminate the cron workflow
+	s.expectCronWorkflowHistories()
+	s.frontendClient.EXPECT().CreateSchedule(gomock.Any(), gomock.Any()).Return(nil, serviceerror.NewWorkflowExecutionAlreadyStarted("schedule exists", "", ""))
+	s.frontendClient.EXPECT().DescribeSchedule(gomock.Any(), gomock.Any()).Return(&workflowservice.DescribeScheduleResponse{
+		Schedule: &schedpb.Schedule{State: &schedpb.ScheduleState{Notes: "migrated from cron workflow test-list-open-workflow-id"}},
+	}, nil)
+	s.frontendClient.EXPECT().TerminateWorkflowExecution(gomock.Any(), gomock.Any()).Return(&workflowservice.TerminateWorkflowExecutionResponse{}, nil)
+
+	err := s.app.Run([]string{"", "--namespace", cliTestNamespace, "schedule", "migrate-cron", "--query", "WorkflowType = 'ReportWorkflow'", "--terminate-old", "--yes"})
+	s.Nil(err)
+}
+
+func (s *cliAppSuite) TestMigrateCronWorkflows_OtherScheduleExists() {
+	s.expectCronWorkflowHistories()
+	s.frontendClient.EXPECT().CreateSchedule(gomock.Any(), gomock.Any()).Return(nil, serviceerror.NewWorkflowExecutionAlreadyStarted("schedule exists", "", ""))
+	s.frontendClient.EXPECT().DescribeSchedule(gomock.Any(), gomock.Any()).Return(&workflowservice.DescribeScheduleResponse{
+		Schedule: &schedpb.Schedule{Action: &schedpb.ScheduleAction{Action: &schedpb.ScheduleAction_StartWorkflow{
+			StartWorkflow: &workflowpb.NewWorkflowExecutionInfo{WorkflowId: "other", WorkflowType: &commonpb.WorkflowType{Name: "OtherWorkflow"}},
+		}}},
+	}, nil)
+
+	err := s.app.Run([]string{"", "--namespace", cliTestNamespace, "schedule", "migrate-cron", "--query", "WorkflowType = 'ReportWorkflow'", "--terminate-old", "--yes"})
+	s.Nil(err)
+}
+
+func (s *cliAppSuite) TestMigrateCronWorkflows_DryRun() {
+	s.expectCronWorkflowHistories()
+	err := s.app.Run([]string{"", "--namespace", cliTestNamespace, "schedule", "migrate-cron", "--query", "WorkflowType = 'ReportWorkflow'", "--dry-run"})
+	s.Nil(err)
+	s.sdkClient.AssertExpectations(s.T())
+}
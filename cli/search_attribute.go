@@ -73,6 +73,35 @@ func newSearchAttributeCommands() []*cli.Command {
 				return ListSearchAttributes(c)
 			},
 		},
+		{
+			Name:        "apply",
+			Usage:       "Add the custom search attributes of a YAML or JSON file to several namespaces",
+			Description: "The file lists namespaces and the custom search attributes they must have. Missing search attributes are added, and with --prune custom search attributes that are not in the file are removed. Nothing is changed if a search attribute exists with another type",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     FlagFile,
+					Aliases:  FlagFileAlias,
+					Usage:    "Search attribute definition file",
+					Required: true,
+				},
+				&cli.BoolFlag{
+					Name:  FlagPrune,
+					Usage: "Remove custom search attributes that are not in the file",
+				},
+				&cli.BoolFlag{
+					Name:  FlagDryRun,
+					Usage: "Show what would change without applying it",
+				},
+				&cli.BoolFlag{
+					Name:    FlagYes,
+					Aliases: FlagYesAlias,
+					Usage:   "Confirm all prompts",
+				},
+			},
+			Action: func(c *cli.Context) error {
+				return ApplySearchAttributes(c)
+			},
+		},
 		{
 			Name:  "remove",
 			Usage: "Remove custom search attributes metadata only (Elasticsearch index schema is not modified)",
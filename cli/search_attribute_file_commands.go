@@ -0,0 +1,217 @@
+// The MIT License
+//
+// Copyright (c) 2022 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/temporalio/tctl-kit/pkg/color"
+	"github.com/urfave/cli/v2"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/operatorservice/v1"
+)
+
+// searchAttributeFile maps namespaces to the custom search attributes they must have
+type searchAttributeFile struct {
+	SearchAttributes []*searchAttributeFileEntry `yaml:"searchAttributes"`
+}
+
+type searchAttributeFileEntry struct {
+	Namespaces []string `yaml:"namespaces"`
+	// Attributes maps search attribute names to types such as Keyword or Int
+	Attributes map[string]string `yaml:"attributes"`
+}
+
+// searchAttributePlan is what apply does to the search attributes of one namespace
+type searchAttributePlan struct {
+	namespace string
+	add       map[string]enumspb.IndexedValueType
+	extra     map[string]enumspb.IndexedValueType
+}
+
+// ApplySearchAttributes adds the custom search attributes of a file that are missing in each namespace,
+// and with --prune removes the custom search attributes that are not in the file
+func ApplySearchAttributes(c *cli.Context) error {
+	desired, err := readSearchAttributeFile(c.String(FlagFile))
+	if err != nil {
+		return err
+	}
+	prune := c.Bool(FlagPrune)
+
+	namespaces := make([]string, 0, len(desired))
+	for ns := range desired {
+		namespaces = append(namespaces, ns)
+	}
+	sort.Strings(namespaces)
+
+	client := cFactory.OperatorClient(c)
+	var plans []*searchAttributePlan
+	var conflicts []string
+	for _, ns := range namespaces {
+		ctx, cancel := newContext(c)
+		resp, err := client.ListSearchAttributes(ctx, &operatorservice.ListSearchAttributesRequest{Namespace: ns})
+		cancel()
+		if err != nil {
+			return fmt.Errorf("unable to list search attributes of namespace %s: %w", ns, err)
+		}
+
+		plan := &searchAttributePlan{
+			namespace: ns,
+			add:       make(map[string]enumspb.IndexedValueType),
+			extra:     make(map[string]enumspb.IndexedValueType),
+		}
+		for name, saType := range desired[ns] {
+			if _, ok := resp.GetSystemAttributes()[name]; ok {
+				conflicts = append(conflicts, fmt.Sprintf("%s is a system search attribute and can't be added to namespace %s", name, ns))
+				continue
+			}
+			current, ok := resp.GetCustomAttributes()[name]
+			if !ok {
+				plan.add[name] = saType
+			} else if current != saType {
+				conflicts = append(conflicts, fmt.Sprintf("%s is %s in namespace %s, not %s", name, current, ns, saType))
+			}
+		}
+		for name, saType := range resp.GetCustomAttributes() {
+			if _, ok := desired[ns][name]; !ok {
+				plan.extra[name] = saType
+			}
+		}
+		plans = append(plans, plan)
+	}
+	if len(conflicts) > 0 {
+		return fmt.Errorf("the search attributes of the file conflict with existing ones, and the type of a search attribute can't be changed "+
+			"as workflows already recorded values of the current type. Use a new name for the new type instead:\n  %s", strings.Join(conflicts, "\n  "))
+	}
+
+	pending := 0
+	for _, plan := range plans {
+		changes := formatSearchAttributeChanges(plan, prune)
+		if len(changes) == 0 {
+			fmt.Printf("Search attributes of namespace %s are up to date\n", plan.namespace)
+			continue
+		}
+		if len(plan.add) > 0 || (prune && len(plan.extra) > 0) {
+			pending++
+		}
+		fmt.Printf("Namespace %s:\n", plan.namespace)
+		for _, change := range changes {
+			fmt.Printf("  %s\n", change)
+		}
+	}
+	if pending == 0 || c.Bool(FlagDryRun) {
+		return nil
+	}
+	if !promptYes(fmt.Sprintf("Apply search attribute changes to %v namespaces? Y/N", color.Yellow(c, "%v", pending)), c.Bool(FlagYes)) {
+		return nil
+	}
+
+	for _, plan := range plans {
+		if len(plan.add) > 0 {
+			ctx, cancel := newContextWithTimeout(c, addSearchAttributesTimeout)
+			_, err := client.AddSearchAttributes(ctx, &operatorservice.AddSearchAttributesRequest{
+				SearchAttributes: plan.add,
+				Namespace:        plan.namespace,
+			})
+			cancel()
+			if err != nil {
+				return fmt.Errorf("unable to add search attributes to namespace %s: %w", plan.namespace, err)
+			}
+		}
+		if prune && len(plan.extra) > 0 {
+			ctx, cancel := newContext(c)
+			_, err := client.RemoveSearchAttributes(ctx, &operatorservice.RemoveSearchAttributesRequest{
+				SearchAttributes: sortedSearchAttributeNames(plan.extra),
+				Namespace:        plan.namespace,
+			})
+			cancel()
+			if err != nil {
+				return fmt.Errorf("unable to remove search attributes from namespace %s: %w", plan.namespace, err)
+			}
+		}
+	}
+	fmt.Println(color.Green(c, "Search attributes have been applied to %d namespaces", pending))
+	return nil
+}
+
+func formatSearchAttributeChanges(plan *searchAttributePlan, prune bool) []string {
+	var changes []string
+	for _, name := range sortedSearchAttributeNames(plan.add) {
+		changes = append(changes, fmt.Sprintf("+ %s (%s)", name, plan.add[name]))
+	}
+	for _, name := range sortedSearchAttributeNames(plan.extra) {
+		if prune {
+			changes = append(changes, fmt.Sprintf("- %s (%s)", name, plan.extra[name]))
+		} else {
+			changes = append(changes, fmt.Sprintf("  %s (%s) is not in the file, use --%s to remove it", name, plan.extra[name], FlagPrune))
+		}
+	}
+	return changes
+}
+
+func sortedSearchAttributeNames(attributes map[string]enumspb.IndexedValueType) []string {
+	names := make([]string, 0, len(attributes))
+	for name := range attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// readSearchAttributeFile returns the search attributes each namespace of the file must have
+func readSearchAttributeFile(path string) (map[string]map[string]enumspb.IndexedValueType, error) {
+	var file searchAttributeFile
+	if err := readDefinitionFile(path, "search attribute", &file); err != nil {
+		return nil, err
+	}
+
+	desired := make(map[string]map[string]enumspb.IndexedValueType)
+	for i, entry := range file.SearchAttributes {
+		if len(entry.Namespaces) == 0 {
+			return nil, fmt.Errorf("entry %d of the file has no namespaces", i+1)
+		}
+		for _, ns := range entry.Namespaces {
+			if desired[ns] == nil {
+				desired[ns] = make(map[string]enumspb.IndexedValueType)
+			}
+		}
+		for name, typeStr := range entry.Attributes {
+			typeInt, err := stringToEnum(typeStr, enumspb.IndexedValueType_value)
+			if err != nil || enumspb.IndexedValueType(typeInt) == enumspb.INDEXED_VALUE_TYPE_UNSPECIFIED {
+				return nil, fmt.Errorf("invalid type %q of search attribute %s, valid types are %v", typeStr, name, allowedEnumValues(enumspb.IndexedValueType_name))
+			}
+			saType := enumspb.IndexedValueType(typeInt)
+			for _, ns := range entry.Namespaces {
+				if existing, ok := desired[ns][name]; ok && existing != saType {
+					return nil, fmt.Errorf("search attribute %s of namespace %s is defined as both %s and %s", name, ns, existing, saType)
+				}
+				desired[ns][name] = saType
+			}
+		}
+	}
+	return desired, nil
+}
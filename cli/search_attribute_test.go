@@ -26,6 +26,7 @@ package cli
 
 import (
 	"github.com/golang/mock/gomock"
+	enumspb "go.temporal.io/api/enums/v1"
 	"go.temporal.io/api/operatorservice/v1"
 )
 
@@ -38,3 +39,66 @@ func (s *cliAppSuite) TestListSearchAttributes() {
 	err = s.app.Run([]string{"", "--namespace", cliTestNamespace, "search-attribute", "list"})
 	s.Nil(err)
 }
+
+const testSearchAttributeFile = `
+searchAttributes:
+  - namespaces: [staging, prod]
+    attributes:
+      CustomerId: Keyword
+      OrderTotal: double
+  - namespaces: [prod]
+    attributes:
+      Region: Keyword
+`
+
+func (s *cliAppSuite) TestApplySearchAttributes() {
+	s.operatorClient.EXPECT().ListSearchAttributes(gomock.Any(), &operatorservice.ListSearchAttributesRequest{Namespace: "prod"}).Return(&operatorservice.ListSearchAttributesResponse{
+		CustomAttributes: map[string]enumspb.IndexedValueType{
+			"CustomerId": enumspb.INDEXED_VALUE_TYPE_KEYWORD,
+			"Legacy":     enumspb.INDEXED_VALUE_TYPE_TEXT,
+		},
+	}, nil)
+	s.operatorClient.EXPECT().ListSearchAttributes(gomock.Any(), &operatorservice.ListSearchAttributesRequest{Namespace: "staging"}).Return(&operatorservice.ListSearchAttributesResponse{
+		CustomAttributes: map[string]enumspb.IndexedValueType{
+			"CustomerId": enumspb.INDEXED_VALUE_TYPE_KEYWORD,
+			"OrderTotal": enumspb.INDEXED_VALUE_TYPE_DOUBLE,
+		},
+	}, nil)
+	s.operatorClient.EXPECT().AddSearchAttributes(gomock.Any(), &operatorservice.AddSearchAttributesRequest{
+		Namespace: "prod",
+		SearchAttributes: map[string]enumspb.IndexedValueType{
+			"OrderTotal": enumspb.INDEXED_VALUE_TYPE_DOUBLE,
+			"Region":     enumspb.INDEXED_VALUE_TYPE_KEYWORD,
+		},
+	}).Return(&operatorservice.AddSearchAttributesResponse{}, nil)
+	s.operatorClient.EXPECT().RemoveSearchAttributes(gomock.Any(), &operatorservice.RemoveSearchAttributesRequest{
+		Namespace:        "prod",
+		SearchAttributes: []string{"Legacy"},
+	}).Return(&operatorservice.RemoveSearchAttributesResponse{}, nil)
+
+	err := s.app.Run([]string{"", "search-attribute", "apply", "--prune", "--yes", "-f", s.writeTempFile("attributes.yaml", testSearchAttributeFile)})
+	s.NoError(err)
+}
+
+func (s *cliAppSuite) TestApplySearchAttributes_TypeChange() {
+	s.operatorClient.EXPECT().ListSearchAttributes(gomock.Any(), &operatorservice.ListSearchAttributesRequest{Namespace: "prod"}).Return(&operatorservice.ListSearchAttributesResponse{
+		CustomAttributes: map[string]enumspb.IndexedValueType{
+			"CustomerId": enumspb.INDEXED_VALUE_TYPE_TEXT,
+		},
+	}, nil)
+	s.operatorClient.EXPECT().ListSearchAttributes(gomock.Any(), &operatorservice.ListSearchAttributesRequest{Namespace: "staging"}).Return(&operatorservice.ListSearchAttributesResponse{}, nil)
+
+	errorCode := s.RunWithExitCode([]string{"", "search-attribute", "apply", "--yes", "-f", s.writeTempFile("attributes.yaml", testSearchAttributeFile)})
+	s.Equal(1, errorCode)
+}
+
+func (s *cliAppSuite) TestApplySearchAttributes_InvalidFile() {
+	for _, content := range []string{
+		"searchAttributes:\n  - attributes:\n      CustomerId: Keyword\n",
+		"searchAttributes:\n  - namespaces: [prod]\n    attributes:\n      CustomerId: Number\n",
+		"searchAttributes:\n  - namespaces: [prod]\n    attributes:\n      CustomerId: Keyword\n  - namespaces: [prod]\n    attributes:\n      CustomerId: Text\n",
+	} {
+		_, err := readSearchAttributeFile(s.writeTempFile("attributes.yaml", content))
+		s.Error(err, content)
+	}
+}
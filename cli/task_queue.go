@@ -37,7 +37,9 @@ func newTaskQueueCommands() []*cli.Command {
 			Usage: "Describe the Workers that have recently polled on this Task Queue",
 			Description: `The Server records the last time of each poll request. Poll requests can last up to a minute, so a LastAccessTime under a minute is normal. If it's over a minute, then likely either the Worker is at capacity (all Workflow and Activity slots are full) or it has shut down. Once it has been 5 minutes since the last poll request, the Worker is removed from the list.
 
-RatePerSecond is the maximum Activities per second the Worker will execute.`,
+RatePerSecond is the maximum Activities per second the Worker will execute.
+
+BacklogCountHint, shown with --include-status, is an approximate number of tasks waiting to be dispatched.`,
 			Flags: append([]cli.Flag{
 				&cli.StringFlag{
 					Name:     FlagTaskQueue,
@@ -50,11 +52,213 @@ RatePerSecond is the maximum Activities per second the Worker will execute.`,
 					Value: "workflow",
 					Usage: "Task Queue type [workflow|activity]",
 				},
+				&cli.BoolFlag{
+					Name:  FlagAllTypes,
+					Usage: "Describe both the workflow and activity Task Queues",
+				},
+				&cli.BoolFlag{
+					Name:  FlagIncludeStatus,
+					Usage: "Include the backlog count, read and ack levels and dispatch rate of the Task Queue",
+				},
+				&cli.IntFlag{
+					Name:  FlagWatch,
+					Usage: "Refresh every N seconds and highlight Workers that stopped polling, until interrupted with Ctrl+C or --count is reached",
+				},
+				&cli.IntFlag{
+					Name:  FlagCount,
+					Usage: "With --watch, stop after this many refreshes",
+				},
 			}, flags.FlagsForRendering...),
 			Action: func(c *cli.Context) error {
 				return DescribeTaskQueue(c)
 			},
 		},
+		{
+			Name:        "check",
+			Usage:       "Check the pollers and backlog of Task Queues and fail when a threshold is breached",
+			Description: "Describes both the workflow and activity Task Queues of each queue and exits with a non-zero code if any of them is unhealthy. Pollers that have not polled within --max-last-access are stale and do not count towards --min-pollers.",
+			Flags: append([]cli.Flag{
+				&cli.StringSliceFlag{
+					Name:     FlagTaskQueue,
+					Aliases:  FlagTaskQueueAlias,
+					Usage:    "Task Queue names, comma separated or repeated",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:  FlagTaskQueueType,
+					Usage: "Only check this Task Queue type [workflow|activity], both if not set",
+				},
+				&cli.IntFlag{
+					Name:  FlagMinPollers,
+					Value: 1,
+					Usage: "Minimum number of Workers polling each Task Queue",
+				},
+				&cli.StringFlag{
+					Name:  FlagMaxLastAccess,
+					Usage: "Maximum time since a Worker last polled, e.g. 1m",
+				},
+				&cli.Int64Flag{
+					Name:  FlagMaxBacklog,
+					Usage: "Maximum number of tasks waiting in each Task Queue",
+				},
+				&cli.IntFlag{
+					Name:  FlagConcurrency,
+					Usage: "Number of Task Queues described at once",
+					Value: 10,
+				},
+				&cli.Float64Flag{
+					Name:  FlagRPS,
+					Usage: "Maximum requests per second, unlimited if not set",
+				},
+			}, flags.FlagsForRendering...),
+			Action: func(c *cli.Context) error {
+				return CheckTaskQueues(c)
+			},
+		},
+		{
+			Name:        "list",
+			Usage:       "List the Task Queues of Workflow Executions matching a Query",
+			Description: "Task Queues are found by scanning Workflow visibility, so only Task Queues that Workflows were started on are listed.",
+			Flags: append([]cli.Flag{
+				&cli.StringFlag{
+					Name:    FlagQuery,
+					Aliases: FlagQueryAlias,
+					Usage:   FlagQueryUsage,
+				},
+				&cli.BoolFlag{
+					Name:  FlagWithPollers,
+					Usage: "Describe each Task Queue and show the number of Workers polling it",
+				},
+				&cli.IntFlag{
+					Name:  FlagConcurrency,
+					Usage: "Number of Task Queues described at once",
+					Value: 10,
+				},
+			}, flags.FlagsForRendering...),
+			Action: func(c *cli.Context) error {
+				return ListTaskQueues(c)
+			},
+		},
+		{
+			Name:  "update-build-ids",
+			Usage: "Update the worker build IDs of a Task Queue",
+			Subcommands: []*cli.Command{
+				{
+					Name:  "add-new-default",
+					Usage: "Add a new build ID, incompatible with the existing ones, and make it the default",
+					Flags: []cli.Flag{
+						&cli.StringFlag{
+							Name:     FlagTaskQueue,
+							Aliases:  FlagTaskQueueAlias,
+							Usage:    "Task Queue name",
+							Required: true,
+						},
+						&cli.StringFlag{
+							Name:     FlagBuildID,
+							Usage:    "The new build ID",
+							Required: true,
+						},
+					},
+					Action: func(c *cli.Context) error {
+						return AddNewDefaultBuildID(c)
+					},
+				},
+				{
+					Name:  "add-new-compatible",
+					Usage: "Add a new build ID compatible with an existing one",
+					Flags: []cli.Flag{
+						&cli.StringFlag{
+							Name:     FlagTaskQueue,
+							Aliases:  FlagTaskQueueAlias,
+							Usage:    "Task Queue name",
+							Required: true,
+						},
+						&cli.StringFlag{
+							Name:     FlagBuildID,
+							Usage:    "The new build ID",
+							Required: true,
+						},
+						&cli.StringFlag{
+							Name:     FlagExistingCompatibleBuildID,
+							Usage:    "A build ID the new build ID is compatible with",
+							Required: true,
+						},
+						&cli.BoolFlag{
+							Name:  FlagSetAsDefault,
+							Usage: "Make the set of the new build ID the default",
+						},
+					},
+					Action: func(c *cli.Context) error {
+						return AddNewCompatibleBuildID(c)
+					},
+				},
+				{
+					Name:  "promote-set",
+					Usage: "Make the set containing an existing build ID the default",
+					Flags: []cli.Flag{
+						&cli.StringFlag{
+							Name:     FlagTaskQueue,
+							Aliases:  FlagTaskQueueAlias,
+							Usage:    "Task Queue name",
+							Required: true,
+						},
+						&cli.StringFlag{
+							Name:     FlagBuildID,
+							Usage:    "A build ID of the set to promote",
+							Required: true,
+						},
+					},
+					Action: func(c *cli.Context) error {
+						return PromoteBuildIDSet(c)
+					},
+				},
+			},
+		},
+		{
+			Name:  "get-build-ids",
+			Usage: "Show the sets of compatible worker build IDs of a Task Queue, the default set first",
+			Flags: append([]cli.Flag{
+				&cli.StringFlag{
+					Name:     FlagTaskQueue,
+					Aliases:  FlagTaskQueueAlias,
+					Usage:    "Task Queue name",
+					Required: true,
+				},
+				&cli.IntFlag{
+					Name:  FlagMaxDepth,
+					Usage: "Maximum number of build IDs to return, all if not set",
+				},
+			}, flags.FlagsForRendering...),
+			Action: func(c *cli.Context) error {
+				return GetBuildIDs(c)
+			},
+		},
+		{
+			Name:        "get-build-id-reachability",
+			Usage:       "Show whether a worker build ID may still receive tasks",
+			Description: "NewWorkflows is true when the build ID is the default of the Task Queue. Open and closed Workflows of the Task Queue are counted by the BinaryChecksums Search Attribute, which holds the build IDs of the Workers that processed them.",
+			Flags: append([]cli.Flag{
+				&cli.StringFlag{
+					Name:     FlagTaskQueue,
+					Aliases:  FlagTaskQueueAlias,
+					Usage:    "Task Queue name",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:     FlagBuildID,
+					Usage:    "The build ID",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:    FlagQuery,
+					Aliases: FlagQueryAlias,
+					Usage:   "Only count Workflow Executions matching this List Filter",
+				},
+			}, flags.FlagsForRendering...),
+			Action: func(c *cli.Context) error {
+				return GetBuildIDReachability(c)
+			},
+		},
 		{
 			Name:  "list-partition",
 			Usage: "List the Task Queue's partitions and which matching node they are assigned to",
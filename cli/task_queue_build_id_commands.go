@@ -0,0 +1,211 @@
+// The MIT License
+//
+// Copyright (c) 2022 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/temporalio/tctl-kit/pkg/color"
+	"github.com/temporalio/tctl-kit/pkg/output"
+	"github.com/urfave/cli/v2"
+	taskqueuepb "go.temporal.io/api/taskqueue/v1"
+	"go.temporal.io/api/workflowservice/v1"
+)
+
+// buildIDSet is a set of compatible build IDs, newest first
+type buildIDSet struct {
+	BuildIds  []string
+	IsDefault bool
+}
+
+// buildIDReachability tells whether workflows may still be processed by workers with a build ID
+type buildIDReachability struct {
+	BuildId         string
+	NewWorkflows    bool
+	OpenWorkflows   int64
+	ClosedWorkflows int64
+}
+
+// AddNewDefaultBuildID adds a new build ID, incompatible with existing ones, as the default of a task queue
+func AddNewDefaultBuildID(c *cli.Context) error {
+	return updateBuildIDOrdering(c, &taskqueuepb.VersionId{WorkerBuildId: c.String(FlagBuildID)}, nil, true)
+}
+
+// AddNewCompatibleBuildID adds a new build ID compatible with an existing one
+func AddNewCompatibleBuildID(c *cli.Context) error {
+	return updateBuildIDOrdering(c,
+		&taskqueuepb.VersionId{WorkerBuildId: c.String(FlagBuildID)},
+		&taskqueuepb.VersionId{WorkerBuildId: c.String(FlagExistingCompatibleBuildID)},
+		c.Bool(FlagSetAsDefault))
+}
+
+// PromoteBuildIDSet makes the set of an existing build ID the default of a task queue
+func PromoteBuildIDSet(c *cli.Context) error {
+	return updateBuildIDOrdering(c, &taskqueuepb.VersionId{WorkerBuildId: c.String(FlagBuildID)}, nil, true)
+}
+
+func updateBuildIDOrdering(c *cli.Context, versionID *taskqueuepb.VersionId, previousCompatible *taskqueuepb.VersionId, becomeDefault bool) error {
+	namespace, err := requiredFlag(c, FlagNamespace)
+	if err != nil {
+		return err
+	}
+	frontendClient := cFactory.FrontendClient(c)
+	ctx, cancel := newContext(c)
+	defer cancel()
+
+	_, err = frontendClient.UpdateWorkerBuildIdOrdering(ctx, &workflowservice.UpdateWorkerBuildIdOrderingRequest{
+		Namespace:          namespace,
+		TaskQueue:          c.String(FlagTaskQueue),
+		VersionId:          versionID,
+		PreviousCompatible: previousCompatible,
+		BecomeDefault:      becomeDefault,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to update build IDs: %w", err)
+	}
+	fmt.Println(color.Green(c, "Updated build IDs of task queue %s", c.String(FlagTaskQueue)))
+	return nil
+}
+
+// GetBuildIDs prints the sets of compatible build IDs of a task queue
+func GetBuildIDs(c *cli.Context) error {
+	resp, err := getBuildIDOrdering(c, int32(c.Int(FlagMaxDepth)))
+	if err != nil {
+		return err
+	}
+
+	var items []interface{}
+	for _, set := range buildIDSets(resp) {
+		items = append(items, set)
+	}
+	return output.PrintItems(c, items, &output.PrintOptions{
+		Fields: []string{"BuildIds", "IsDefault"},
+	})
+}
+
+// GetBuildIDReachability prints whether a build ID may still receive tasks. Workflows are counted
+// on the task queue by the BinaryChecksums search attribute, which holds the build IDs of the workers that processed them.
+func GetBuildIDReachability(c *cli.Context) error {
+	namespace, err := requiredFlag(c, FlagNamespace)
+	if err != nil {
+		return err
+	}
+	buildID := c.String(FlagBuildID)
+	resp, err := getBuildIDOrdering(c, 0)
+	if err != nil {
+		return err
+	}
+	sets := buildIDSets(resp)
+
+	sdkClient, err := getSDKClient(c)
+	if err != nil {
+		return err
+	}
+	count := func(statusOperator string) (int64, error) {
+		ctx, cancel := newContext(c)
+		defer cancel()
+		query := fmt.Sprintf("TaskQueue = '%s' AND BinaryChecksums = '%s' AND ExecutionStatus %s 'Running'",
+			escapeQueryValue(c.String(FlagTaskQueue)), escapeQueryValue(buildID), statusOperator)
+		if c.String(FlagQuery) != "" {
+			query = fmt.Sprintf("(%s) AND %s", c.String(FlagQuery), query)
+		}
+		resp, err := sdkClient.CountWorkflow(ctx, &workflowservice.CountWorkflowExecutionsRequest{
+			Namespace: namespace,
+			Query:     query,
+		})
+		if err != nil {
+			return 0, fmt.Errorf("unable to count workflows: %w", err)
+		}
+		return resp.GetCount(), nil
+	}
+
+	reachability := &buildIDReachability{
+		BuildId:      buildID,
+		NewWorkflows: len(sets) > 0 && sets[0].IsDefault && sets[0].BuildIds[0] == buildID,
+	}
+	if reachability.OpenWorkflows, err = count("="); err != nil {
+		return err
+	}
+	if reachability.ClosedWorkflows, err = count("!="); err != nil {
+		return err
+	}
+	return output.PrintItems(c, []interface{}{reachability}, &output.PrintOptions{
+		Fields:       []string{"BuildId", "NewWorkflows", "OpenWorkflows", "ClosedWorkflows"},
+		OutputFormat: output.Card,
+	})
+}
+
+func getBuildIDOrdering(c *cli.Context, maxDepth int32) (*workflowservice.GetWorkerBuildIdOrderingResponse, error) {
+	namespace, err := requiredFlag(c, FlagNamespace)
+	if err != nil {
+		return nil, err
+	}
+	frontendClient := cFactory.FrontendClient(c)
+	ctx, cancel := newContext(c)
+	defer cancel()
+
+	resp, err := frontendClient.GetWorkerBuildIdOrdering(ctx, &workflowservice.GetWorkerBuildIdOrderingRequest{
+		Namespace: namespace,
+		TaskQueue: c.String(FlagTaskQueue),
+		MaxDepth:  maxDepth,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to get build IDs: %w", err)
+	}
+	return resp, nil
+}
+
+// buildIDSets flattens the version graph into sets of compatible build IDs, the default set first.
+// A set is the chain of compatible versions ending at a leaf; the node that started a set links
+// to the leaf of the previous incompatible set.
+func buildIDSets(resp *workflowservice.GetWorkerBuildIdOrderingResponse) []*buildIDSet {
+	var sets []*buildIDSet
+	visited := make(map[string]bool)
+	leaves := append([]*taskqueuepb.VersionIdNode{resp.GetCurrentDefault()}, resp.GetCompatibleLeaves()...)
+	for i := 0; i < len(leaves); i++ {
+		set := &buildIDSet{IsDefault: i == 0}
+		for node := leaves[i]; node != nil; node = node.GetPreviousCompatible() {
+			id := node.GetVersion().GetWorkerBuildId()
+			if visited[id] {
+				break
+			}
+			visited[id] = true
+			set.BuildIds = append(set.BuildIds, id)
+			if previous := node.GetPreviousIncompatible(); previous != nil {
+				leaves = append(leaves, previous)
+			}
+		}
+		if len(set.BuildIds) > 0 {
+			sets = append(sets, set)
+		}
+	}
+	return sets
+}
+
+// escapeQueryValue escapes a value for use inside a single-quoted visibility query string literal.
+func escapeQueryValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value)
+}
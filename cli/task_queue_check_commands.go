@@ -0,0 +1,146 @@
+// The MIT License
+//
+// Copyright (c) 2022 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/temporalio/tctl-kit/pkg/output"
+	"github.com/urfave/cli/v2"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/server/common/primitives/timestamp"
+)
+
+// taskQueueHealth is the result of checking a task queue of a given type
+type taskQueueHealth struct {
+	TaskQueue     string
+	TaskQueueType enumspb.TaskQueueType
+	Pollers       int
+	StalePollers  int
+	Backlog       int64
+	Healthy       bool
+	Problems      string
+}
+
+type taskQueueThresholds struct {
+	minPollers    int
+	maxLastAccess time.Duration
+	maxBacklog    int64
+}
+
+// CheckTaskQueues checks the pollers and backlog of task queues against thresholds
+// and fails when any of them is breached
+func CheckTaskQueues(c *cli.Context) error {
+	thresholds := taskQueueThresholds{minPollers: c.Int(FlagMinPollers), maxBacklog: -1}
+	if c.IsSet(FlagMaxLastAccess) {
+		d, err := timestamp.ParseDuration(c.String(FlagMaxLastAccess))
+		if err != nil {
+			return fmt.Errorf("unable to parse %s: %w", FlagMaxLastAccess, err)
+		}
+		thresholds.maxLastAccess = d
+	}
+	if c.IsSet(FlagMaxBacklog) {
+		thresholds.maxBacklog = c.Int64(FlagMaxBacklog)
+	}
+
+	taskQueueTypes := []enumspb.TaskQueueType{enumspb.TASK_QUEUE_TYPE_WORKFLOW, enumspb.TASK_QUEUE_TYPE_ACTIVITY}
+	if c.IsSet(FlagTaskQueueType) {
+		taskQueueTypes = []enumspb.TaskQueueType{strToTaskQueueType(c.String(FlagTaskQueueType))}
+	}
+	// the backlog is only returned along with the task queue status
+	describe, err := newTaskQueueDescriber(c, thresholds.maxBacklog >= 0)
+	if err != nil {
+		return err
+	}
+
+	var results []*taskQueueHealth
+	for _, value := range c.StringSlice(FlagTaskQueue) {
+		for _, taskQueue := range strings.Split(value, ",") {
+			taskQueue = strings.TrimSpace(taskQueue)
+			if taskQueue == "" {
+				continue
+			}
+			for _, taskQueueType := range taskQueueTypes {
+				results = append(results, &taskQueueHealth{TaskQueue: taskQueue, TaskQueueType: taskQueueType})
+			}
+		}
+	}
+
+	now := time.Now()
+	runConcurrently(len(results), c.Int(FlagConcurrency), c.Float64(FlagRPS), func(i int) {
+		result := results[i]
+		resp, err := describe(result.TaskQueue, result.TaskQueueType)
+		if err != nil {
+			result.Problems = fmt.Sprintf("unable to describe task queue: %v", err)
+			return
+		}
+		checkTaskQueueHealth(result, resp, thresholds, now)
+	})
+
+	unhealthy := 0
+	items := make([]interface{}, len(results))
+	for i, r := range results {
+		if !r.Healthy {
+			unhealthy++
+		}
+		items[i] = r
+	}
+	err = output.PrintItems(c, items, &output.PrintOptions{
+		Fields: []string{"TaskQueue", "TaskQueueType", "Pollers", "StalePollers", "Backlog", "Healthy", "Problems"},
+	})
+	if err != nil {
+		return err
+	}
+	if unhealthy > 0 {
+		return fmt.Errorf("%d of %d task queues are unhealthy", unhealthy, len(results))
+	}
+	return nil
+}
+
+// checkTaskQueueHealth fills in the result for a task queue description. Pollers that have not
+// polled within the max last access are stale and do not count towards the minimum pollers.
+func checkTaskQueueHealth(result *taskQueueHealth, resp *workflowservice.DescribeTaskQueueResponse, thresholds taskQueueThresholds, now time.Time) {
+	for _, p := range resp.GetPollers() {
+		if thresholds.maxLastAccess > 0 && now.Sub(timestamp.TimeValue(p.GetLastAccessTime())) > thresholds.maxLastAccess {
+			result.StalePollers++
+			continue
+		}
+		result.Pollers++
+	}
+	result.Backlog = resp.GetTaskQueueStatus().GetBacklogCountHint()
+
+	var problems []string
+	if result.Pollers < thresholds.minPollers {
+		problems = append(problems, fmt.Sprintf("%d pollers, expected at least %d", result.Pollers, thresholds.minPollers))
+	}
+	if thresholds.maxBacklog >= 0 && result.Backlog > thresholds.maxBacklog {
+		problems = append(problems, fmt.Sprintf("backlog of %d, expected at most %d", result.Backlog, thresholds.maxBacklog))
+	}
+	result.Problems = strings.Join(problems, "; ")
+	result.Healthy = len(problems) == 0
+}
@@ -26,18 +26,83 @@ package cli
 
 import (
 	"fmt"
+	"time"
 
 	enumspb "go.temporal.io/api/enums/v1"
 	taskqueuepb "go.temporal.io/api/taskqueue/v1"
 	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/server/common/primitives/timestamp"
 
 	"github.com/temporalio/tctl-kit/pkg/color"
 	"github.com/temporalio/tctl-kit/pkg/output"
 	"github.com/urfave/cli/v2"
 )
 
+// taskQueuePoller is a poller of a task queue along with the type of tasks it polls for
+type taskQueuePoller struct {
+	TaskQueueType  enumspb.TaskQueueType
+	Identity       string
+	LastAccessTime *time.Time
+	RatePerSecond  float64
+	// WorkerVersioningId is the build ID the worker polls with
+	WorkerVersioningId string
+}
+
+// taskQueueStatus is the backlog status of a task queue of a given type
+type taskQueueStatus struct {
+	TaskQueueType    enumspb.TaskQueueType
+	BacklogCountHint int64
+	ReadLevel        int64
+	AckLevel         int64
+	RatePerSecond    float64
+	LeaseStartTaskId int64
+	LeaseEndTaskId   int64
+}
+
+// taskQueueWatchUnit is the unit of the --watch refresh interval, tests shorten it
+var taskQueueWatchUnit = time.Second
+
 // DescribeTaskQueue show pollers info of a given taskqueue
 func DescribeTaskQueue(c *cli.Context) error {
+	if !c.Bool(FlagAllTypes) && !c.Bool(FlagIncludeStatus) && !c.IsSet(FlagWatch) {
+		return describeTaskQueuePollers(c)
+	}
+
+	taskQueueTypes := []enumspb.TaskQueueType{strToTaskQueueType(c.String(FlagTaskQueueType))}
+	if c.Bool(FlagAllTypes) {
+		taskQueueTypes = []enumspb.TaskQueueType{enumspb.TASK_QUEUE_TYPE_WORKFLOW, enumspb.TASK_QUEUE_TYPE_ACTIVITY}
+	}
+	describe, err := newTaskQueueDescriber(c, c.Bool(FlagIncludeStatus))
+	if err != nil {
+		return err
+	}
+
+	watch := c.Int(FlagWatch)
+	if watch <= 0 {
+		_, err := printTaskQueueDescription(c, describe, c.String(FlagTaskQueue), taskQueueTypes, nil)
+		return err
+	}
+
+	// refreshes until interrupted unless --count is given
+	var previous []*taskQueuePoller
+	for i := 0; !c.IsSet(FlagCount) || i < c.Int(FlagCount); i++ {
+		if i > 0 {
+			time.Sleep(time.Duration(watch) * taskQueueWatchUnit)
+		}
+		fmt.Println(color.Magenta(c, "\n%s\n", time.Now().Format(time.RFC3339)))
+		pollers, err := printTaskQueueDescription(c, describe, c.String(FlagTaskQueue), taskQueueTypes, previous)
+		if err != nil {
+			// keep watching through transient failures, the next refresh may succeed
+			fmt.Println(color.Red(c, "%v", err))
+		} else {
+			previous = pollers
+		}
+	}
+	return nil
+}
+
+// describeTaskQueuePollers prints the pollers of a single task queue type as returned by the server
+func describeTaskQueuePollers(c *cli.Context) error {
 	sdkClient, err := getSDKClient(c)
 	if err != nil {
 		return err
@@ -53,9 +118,7 @@ func DescribeTaskQueue(c *cli.Context) error {
 	}
 
 	opts := &output.PrintOptions{
-		// TODO enable when versioning feature is out
-		// Fields: []string{"Identity", "LastAccessTime", "RatePerSecond", "WorkerVersioningId"},
-		Fields: []string{"Identity", "LastAccessTime", "RatePerSecond"},
+		Fields: []string{"Identity", "LastAccessTime", "RatePerSecond", "WorkerVersioningId"},
 	}
 	var items []interface{}
 	for _, e := range resp.Pollers {
@@ -64,6 +127,121 @@ func DescribeTaskQueue(c *cli.Context) error {
 	return output.PrintItems(c, items, opts)
 }
 
+type taskQueueDescriber func(taskQueue string, taskQueueType enumspb.TaskQueueType) (*workflowservice.DescribeTaskQueueResponse, error)
+
+// newTaskQueueDescriber returns a function describing a task queue of a given type.
+// The SDK client does not ask for the task queue status, so the frontend client is used when it is requested.
+func newTaskQueueDescriber(c *cli.Context, includeStatus bool) (taskQueueDescriber, error) {
+	if includeStatus {
+		namespace, err := requiredFlag(c, FlagNamespace)
+		if err != nil {
+			return nil, err
+		}
+		frontendClient := cFactory.FrontendClient(c)
+		return func(taskQueue string, taskQueueType enumspb.TaskQueueType) (*workflowservice.DescribeTaskQueueResponse, error) {
+			ctx, cancel := newContext(c)
+			defer cancel()
+			return frontendClient.DescribeTaskQueue(ctx, &workflowservice.DescribeTaskQueueRequest{
+				Namespace:              namespace,
+				TaskQueue:              &taskqueuepb.TaskQueue{Name: taskQueue, Kind: enumspb.TASK_QUEUE_KIND_NORMAL},
+				TaskQueueType:          taskQueueType,
+				IncludeTaskQueueStatus: true,
+			})
+		}, nil
+	}
+
+	sdkClient, err := getSDKClient(c)
+	if err != nil {
+		return nil, err
+	}
+	return func(taskQueue string, taskQueueType enumspb.TaskQueueType) (*workflowservice.DescribeTaskQueueResponse, error) {
+		ctx, cancel := newContext(c)
+		defer cancel()
+		return sdkClient.DescribeTaskQueue(ctx, taskQueue, taskQueueType)
+	}, nil
+}
+
+// printTaskQueueDescription prints the status and pollers of the task queue types and
+// highlights the pollers present in previous that are gone. It returns the current pollers.
+func printTaskQueueDescription(c *cli.Context, describe taskQueueDescriber, taskQueue string, taskQueueTypes []enumspb.TaskQueueType, previous []*taskQueuePoller) ([]*taskQueuePoller, error) {
+	var pollers []*taskQueuePoller
+	var statuses []interface{}
+	for _, taskQueueType := range taskQueueTypes {
+		resp, err := describe(taskQueue, taskQueueType)
+		if err != nil {
+			return nil, fmt.Errorf("unable to describe task queue: %w", err)
+		}
+		if status := resp.GetTaskQueueStatus(); status != nil {
+			statuses = append(statuses, &taskQueueStatus{
+				TaskQueueType:    taskQueueType,
+				BacklogCountHint: status.GetBacklogCountHint(),
+				ReadLevel:        status.GetReadLevel(),
+				AckLevel:         status.GetAckLevel(),
+				RatePerSecond:    status.GetRatePerSecond(),
+				LeaseStartTaskId: status.GetTaskIdBlock().GetStartId(),
+				LeaseEndTaskId:   status.GetTaskIdBlock().GetEndId(),
+			})
+		}
+		for _, p := range resp.GetPollers() {
+			pollers = append(pollers, &taskQueuePoller{
+				TaskQueueType:      taskQueueType,
+				Identity:           p.GetIdentity(),
+				LastAccessTime:     p.GetLastAccessTime(),
+				RatePerSecond:      p.GetRatePerSecond(),
+				WorkerVersioningId: p.GetWorkerVersioningId().GetWorkerBuildId(),
+			})
+		}
+	}
+
+	if len(statuses) > 0 {
+		fmt.Println(color.Magenta(c, "Task Queue Status\n"))
+		err := output.PrintItems(c, statuses, &output.PrintOptions{
+			Fields:     []string{"TaskQueueType", "BacklogCountHint", "ReadLevel", "AckLevel", "RatePerSecond"},
+			FieldsLong: []string{"LeaseStartTaskId", "LeaseEndTaskId"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		fmt.Println(color.Magenta(c, "\nPollers\n"))
+	}
+
+	fields := []string{"Identity", "LastAccessTime", "RatePerSecond", "WorkerVersioningId"}
+	if len(taskQueueTypes) > 1 {
+		fields = append([]string{"TaskQueueType"}, fields...)
+	}
+	items := make([]interface{}, len(pollers))
+	for i, p := range pollers {
+		items[i] = p
+	}
+	if err := output.PrintItems(c, items, &output.PrintOptions{Fields: fields}); err != nil {
+		return nil, err
+	}
+
+	for _, p := range goneTaskQueuePollers(previous, pollers) {
+		fmt.Println(color.Red(c, "%s poller %s is gone, last polled at %s", p.TaskQueueType, p.Identity, formatTime(timestamp.TimeValue(p.LastAccessTime), false)))
+	}
+	return pollers, nil
+}
+
+// goneTaskQueuePollers returns the pollers of previous that are not in current
+func goneTaskQueuePollers(previous []*taskQueuePoller, current []*taskQueuePoller) []*taskQueuePoller {
+	type pollerKey struct {
+		taskQueueType enumspb.TaskQueueType
+		identity      string
+	}
+	present := make(map[pollerKey]bool, len(current))
+	for _, p := range current {
+		present[pollerKey{p.TaskQueueType, p.Identity}] = true
+	}
+	var gone []*taskQueuePoller
+	for _, p := range previous {
+		if !present[pollerKey{p.TaskQueueType, p.Identity}] {
+			gone = append(gone, p)
+		}
+	}
+	return gone
+}
+
 // ListTaskQueuePartitions gets all the taskqueue partition and host information.
 func ListTaskQueuePartitions(c *cli.Context) error {
 	frontendClient := cFactory.FrontendClient(c)
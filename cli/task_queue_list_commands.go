@@ -0,0 +1,157 @@
+// The MIT License
+//
+// Copyright (c) 2022 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cli
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/temporalio/tctl-kit/pkg/color"
+	"github.com/temporalio/tctl-kit/pkg/output"
+	"github.com/urfave/cli/v2"
+	enumspb "go.temporal.io/api/enums/v1"
+	workflowpb "go.temporal.io/api/workflow/v1"
+)
+
+// taskQueueUsage is a task queue found in workflow visibility
+type taskQueueUsage struct {
+	TaskQueue       string
+	OpenWorkflows   int
+	ClosedWorkflows int
+	WorkflowPollers int
+	ActivityPollers int
+}
+
+// ListTaskQueues lists the task queues of the workflow executions matching a query
+func ListTaskQueues(c *cli.Context) error {
+	sdkClient, err := getSDKClient(c)
+	if err != nil {
+		return err
+	}
+
+	usages := make(map[string]*taskQueueUsage)
+	query := c.String(FlagQuery)
+	var npt []byte
+	for {
+		var items []interface{}
+		items, npt, err = listWorkflows(c, sdkClient, npt, query)
+		if err != nil {
+			return err
+		}
+		for _, item := range items {
+			countTaskQueueUsage(usages, item.(*workflowpb.WorkflowExecutionInfo))
+		}
+		if len(npt) == 0 {
+			break
+		}
+	}
+
+	var taskQueues []*taskQueueUsage
+	for _, u := range usages {
+		taskQueues = append(taskQueues, u)
+	}
+	sort.Slice(taskQueues, func(i, j int) bool {
+		return taskQueues[i].TaskQueue < taskQueues[j].TaskQueue
+	})
+
+	fields := []string{"TaskQueue", "OpenWorkflows", "ClosedWorkflows"}
+	var failures []string
+	if c.Bool(FlagWithPollers) {
+		fields = append(fields, "WorkflowPollers", "ActivityPollers")
+		if failures, err = countTaskQueuePollers(c, taskQueues); err != nil {
+			return err
+		}
+	}
+
+	items := make([]interface{}, len(taskQueues))
+	for i, u := range taskQueues {
+		items[i] = u
+	}
+	if err := output.PrintItems(c, items, &output.PrintOptions{Fields: fields}); err != nil {
+		return err
+	}
+
+	if c.Bool(FlagWithPollers) {
+		for _, u := range taskQueues {
+			if u.OpenWorkflows > 0 && u.WorkflowPollers == 0 {
+				fmt.Println(color.Yellow(c, "Task queue %s has %d open workflows and no workers polling it", u.TaskQueue, u.OpenWorkflows))
+			}
+		}
+	}
+	for _, f := range failures {
+		fmt.Println(color.Red(c, "Failed to describe %s", f))
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("unable to describe %d task queues", len(failures))
+	}
+	return nil
+}
+
+func countTaskQueueUsage(usages map[string]*taskQueueUsage, info *workflowpb.WorkflowExecutionInfo) {
+	u, ok := usages[info.GetTaskQueue()]
+	if !ok {
+		u = &taskQueueUsage{TaskQueue: info.GetTaskQueue()}
+		usages[info.GetTaskQueue()] = u
+	}
+	if info.GetStatus() == enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING {
+		u.OpenWorkflows++
+	} else {
+		u.ClosedWorkflows++
+	}
+}
+
+// countTaskQueuePollers describes the workflow and activity task queues and sets their poller counts.
+// It returns the task queues that could not be described.
+func countTaskQueuePollers(c *cli.Context, taskQueues []*taskQueueUsage) ([]string, error) {
+	describe, err := newTaskQueueDescriber(c, false)
+	if err != nil {
+		return nil, err
+	}
+
+	taskQueueTypes := []enumspb.TaskQueueType{enumspb.TASK_QUEUE_TYPE_WORKFLOW, enumspb.TASK_QUEUE_TYPE_ACTIVITY}
+	failures := make([]string, len(taskQueues)*len(taskQueueTypes))
+	runConcurrently(len(failures), c.Int(FlagConcurrency), 0, func(i int) {
+		u := taskQueues[i/len(taskQueueTypes)]
+		taskQueueType := taskQueueTypes[i%len(taskQueueTypes)]
+		resp, err := describe(u.TaskQueue, taskQueueType)
+		if err != nil {
+			failures[i] = fmt.Sprintf("%s %s: %v", taskQueueType, u.TaskQueue, err)
+			return
+		}
+		if taskQueueType == enumspb.TASK_QUEUE_TYPE_WORKFLOW {
+			u.WorkflowPollers = len(resp.GetPollers())
+		} else {
+			u.ActivityPollers = len(resp.GetPollers())
+		}
+	})
+
+	var result []string
+	for _, f := range failures {
+		if f != "" {
+			result = append(result, f)
+		}
+	}
+	return result, nil
+}
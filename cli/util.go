@@ -35,6 +35,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/fatih/color"
@@ -47,10 +48,12 @@ import (
 	commonpb "go.temporal.io/api/common/v1"
 	enumspb "go.temporal.io/api/enums/v1"
 	historypb "go.temporal.io/api/history/v1"
+	"go.temporal.io/api/serviceerror"
 	sdkclient "go.temporal.io/sdk/client"
 	"go.temporal.io/sdk/converter"
+	"go.temporal.io/server/common"
 	"go.temporal.io/server/common/codec"
-	"go.temporal.io/server/common/payloads"
+	"golang.org/x/time/rate"
 )
 
 // HistoryEventToString convert HistoryEvent to string
@@ -557,6 +560,9 @@ func unmarshalInputsFromCLI(c *cli.Context) ([]interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
+	if isRawPayloadInput(c) {
+		return rawPayloadInputs(c, jsonsRaw)
+	}
 
 	var result []interface{}
 	for _, jsonRaw := range jsonsRaw {
@@ -581,7 +587,7 @@ func processJSONInput(c *cli.Context) (*commonpb.Payloads, error) {
 	if err != nil {
 		return nil, err
 	}
-	p, err := payloads.Encode(jsons...)
+	p, err := customDataConverter().ToPayloads(jsons...)
 	if err != nil {
 		return nil, fmt.Errorf("unable to encode input: %w", err)
 	}
@@ -706,6 +712,20 @@ func prompt(msg string, autoConfirm bool, expectedInputs ...string) bool {
 	return false
 }
 
+// promptName asks the user to type a name to confirm an action. Unlike prompt, the name is compared case-sensitively.
+func promptName(msg string, autoConfirm bool, name string) bool {
+	fmt.Print(msg, " ")
+	var text string
+	if autoConfirm {
+		text = name
+		fmt.Print(text)
+	} else {
+		text, _ = bufio.NewReader(os.Stdin).ReadString('\n')
+	}
+	fmt.Println()
+	return strings.TrimSpace(text) == name
+}
+
 func encodeMemo(memo map[string]interface{}) (*commonpb.Memo, error) {
 	if len(memo) == 0 {
 		return nil, nil
@@ -797,3 +817,47 @@ func SplitKeyValuePairs(kvs []string) (map[string]string, error) {
 
 	return pairs, nil
 }
+
+// runConcurrently calls fn for every index in [0, n) using at most concurrency goroutines.
+// If rps is positive, calls are started no faster than rps per second.
+func runConcurrently(n int, concurrency int, rps float64, fn func(i int)) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	var limiter *rate.Limiter
+	if rps > 0 {
+		limiter = rate.NewLimiter(rate.Limit(rps), 1)
+	}
+
+	indexes := make(chan int)
+	wg := &sync.WaitGroup{}
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				fn(i)
+			}
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		if limiter != nil {
+			_ = limiter.Wait(context.Background())
+		}
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+}
+
+// isTransientError reports whether a failed request is worth retrying
+func isTransientError(err error) bool {
+	switch err.(type) {
+	case *serviceerror.Unavailable,
+		*serviceerror.ResourceExhausted,
+		*serviceerror.DeadlineExceeded:
+		return true
+	}
+	return common.IsContextDeadlineExceededErr(err)
+}
@@ -25,6 +25,7 @@
 package cli
 
 import (
+	"os"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -204,3 +205,23 @@ func (s *utilSuite) TestParseKeyValuePairs() {
 		})
 	}
 }
+
+func (s *utilSuite) TestPromptName_CaseSensitive() {
+	stdin := os.Stdin
+	defer func() { os.Stdin = stdin }()
+
+	for input, expected := range map[string]bool{
+		"Payments-Prod\n": true,
+		"payments-prod\n": false,
+		"Payments\n":      false,
+	} {
+		r, w, err := os.Pipe()
+		s.NoError(err)
+		_, err = w.WriteString(input)
+		s.NoError(err)
+		s.NoError(w.Close())
+		os.Stdin = r
+		s.Equal(expected, promptName("Type namespace name to confirm:", false, "Payments-Prod"), input)
+		s.NoError(r.Close())
+	}
+}
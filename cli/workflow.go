@@ -49,6 +49,39 @@ func newWorkflowCommands() []*cli.Command {
 				return StartWorkflow(c, true)
 			},
 		},
+		{
+			Name:        "bench",
+			Usage:       "Start many Workflow Executions and report throughput and latency",
+			Description: "Durations in JSON output are in nanoseconds",
+			Flags: append(append(removeFlags(flagsForStartWorkflow, FlagWorkflowID, FlagCronSchedule),
+				&cli.StringFlag{
+					Name:    FlagWorkflowID,
+					Aliases: FlagWorkflowIDAlias,
+					Usage:   "Workflow Id prefix. Executions are started as <prefix>-<n>",
+				},
+				&cli.IntFlag{
+					Name:  FlagCount,
+					Usage: "Number of Workflow Executions to start",
+					Value: 10,
+				},
+				&cli.IntFlag{
+					Name:  FlagConcurrency,
+					Usage: "Maximum number of Workflow Executions started (or awaited) at once",
+					Value: 10,
+				},
+				&cli.Float64Flag{
+					Name:  FlagRPS,
+					Usage: "Maximum start requests per second, unlimited if not set",
+				},
+				&cli.BoolFlag{
+					Name:  FlagWait,
+					Usage: "Wait for every Workflow Execution to close and report end-to-end latency",
+				},
+			), flags.FlagsForRendering...),
+			Action: func(c *cli.Context) error {
+				return BenchWorkflow(c)
+			},
+		},
 		{
 			Name:  "describe",
 			Usage: "Show information about a Workflow Execution",
@@ -61,6 +94,10 @@ func newWorkflowCommands() []*cli.Command {
 					Name:  FlagPrintRaw,
 					Usage: "Print properties as they are stored",
 				},
+				&cli.BoolFlag{
+					Name:  FlagArchive,
+					Usage: "Describe an archived Workflow Execution from its archived history, requires --run-id (EXPERIMENTAL)",
+				},
 			}...),
 			Action: func(c *cli.Context) error {
 				return DescribeWorkflow(c)
@@ -74,6 +111,39 @@ func newWorkflowCommands() []*cli.Command {
 				return ListWorkflow(c)
 			},
 		},
+		{
+			Name:        "export",
+			Usage:       "Export the Event Histories of Workflow Executions matching a Query to a local directory",
+			Description: "Writes one JSON history file per run plus a manifest.ndjson. Running the command again with the same directory resumes the export",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:    FlagQuery,
+					Aliases: FlagQueryAlias,
+					Usage:   FlagQueryUsage,
+				},
+				&cli.StringFlag{
+					Name:     FlagDir,
+					Usage:    "Directory to write the export to",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:  FlagBundle,
+					Usage: "Also bundle the export directory into a .tar.gz file at this path",
+				},
+				&cli.IntFlag{
+					Name:  FlagConcurrency,
+					Usage: "Number of histories downloaded at once",
+					Value: 10,
+				},
+				&cli.Float64Flag{
+					Name:  FlagRPS,
+					Usage: "Maximum history downloads started per second, unlimited if not set",
+				},
+			},
+			Action: func(c *cli.Context) error {
+				return ExportWorkflow(c)
+			},
+		},
 		{
 			Name:  "show",
 			Usage: "Show Event History for a Workflow Execution",
@@ -85,7 +155,7 @@ func newWorkflowCommands() []*cli.Command {
 		{
 			Name:  "query",
 			Usage: "Query a Workflow Execution",
-			Flags: append(flagsForStackTraceQuery,
+			Flags: append(append(flagsForStackTraceQuery, flagsForPayloadInput...),
 				&cli.StringFlag{
 					Name:     FlagType,
 					Usage:    "The query type you want to run",
@@ -107,7 +177,7 @@ func newWorkflowCommands() []*cli.Command {
 		{
 			Name:  "signal",
 			Usage: "Signal Workflow Execution by Id or List Filter",
-			Flags: []cli.Flag{
+			Flags: append([]cli.Flag{
 				&cli.StringFlag{
 					Name:    FlagWorkflowID,
 					Aliases: FlagWorkflowIDAlias,
@@ -124,9 +194,8 @@ func newWorkflowCommands() []*cli.Command {
 					Usage:   "Signal Workflow Executions by List Filter. See https://docs.temporal.io/concepts/what-is-a-list-filter/",
 				},
 				&cli.StringFlag{
-					Name:     FlagName,
-					Usage:    "Signal Name",
-					Required: true,
+					Name:  FlagName,
+					Usage: "Signal Name. Required unless every line of --" + FlagInputFilePerExecution + " sets a name",
 				},
 				&cli.StringFlag{
 					Name:    FlagInput,
@@ -137,6 +206,20 @@ func newWorkflowCommands() []*cli.Command {
 					Name:  FlagInputFile,
 					Usage: "Input for the signal from file (JSON)",
 				},
+				&cli.StringFlag{
+					Name: FlagInputFilePerExecution,
+					Usage: "Signal many Workflow Executions, each with its own input, from a newline delimited JSON file. " +
+						`Each line has the format {"workflowId": "...", "runId": "...", "name": "...", "input": <JSON>}, where runId, name and input are optional`,
+				},
+				&cli.IntFlag{
+					Name:  FlagConcurrency,
+					Value: 10,
+					Usage: "Number of signals sent in parallel when signaling from --" + FlagInputFilePerExecution,
+				},
+				&cli.Float64Flag{
+					Name:  FlagRPS,
+					Usage: "Maximum signals per second when signaling from --" + FlagInputFilePerExecution + ". Unlimited by default",
+				},
 				&cli.StringFlag{
 					Name:  FlagReason,
 					Usage: "Reason for signaling with List Filter",
@@ -146,7 +229,7 @@ func newWorkflowCommands() []*cli.Command {
 					Aliases: FlagYesAlias,
 					Usage:   "Confirm all prompts",
 				},
-			},
+			}, flagsForPayloadInput...),
 			Action: func(c *cli.Context) error {
 				return SignalWorkflow(c)
 			},
@@ -242,9 +325,8 @@ func newWorkflowCommands() []*cli.Command {
 					Usage: "The eventId of any event after WorkflowTaskStarted you want to reset to (exclusive). It can be WorkflowTaskCompleted, WorkflowTaskFailed or others",
 				},
 				&cli.StringFlag{
-					Name:     FlagReason,
-					Usage:    "Reason to reset",
-					Required: true,
+					Name:  FlagReason,
+					Usage: "Reason to reset. Required unless --preview is set",
 				},
 				&cli.StringFlag{
 					Name:  FlagType,
@@ -255,6 +337,10 @@ func newWorkflowCommands() []*cli.Command {
 					Usage: "Event types to reapply after the reset point: " +
 						strings.Join(mapKeysToArray(resetReapplyTypesMap), ", ") + ". (default: All)",
 				},
+				&cli.BoolFlag{
+					Name:  FlagPreview,
+					Usage: "Show the events that the reset would discard or reapply without resetting",
+				},
 			}...),
 			Action: func(c *cli.Context) error {
 				return ResetWorkflow(c)
@@ -314,7 +400,7 @@ func newWorkflowCommands() []*cli.Command {
 				},
 				&cli.BoolFlag{
 					Name:  FlagDryRun,
-					Usage: "Simulate reset without resetting any Workflow Executions",
+					Usage: "Simulate reset without resetting any Workflow Executions, and preview the events each reset would discard or reapply",
 				},
 			},
 			Action: func(c *cli.Context) error {
@@ -0,0 +1,245 @@
+// The MIT License
+//
+// Copyright (c) 2022 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/temporalio/tctl-kit/pkg/color"
+	"github.com/temporalio/tctl-kit/pkg/output"
+	"github.com/temporalio/tctl-kit/pkg/pager"
+	"github.com/urfave/cli/v2"
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	historypb "go.temporal.io/api/history/v1"
+	"go.temporal.io/api/serviceerror"
+	workflowpb "go.temporal.io/api/workflow/v1"
+	"go.temporal.io/api/workflowservice/v1"
+
+	trace "github.com/temporalio/tctl/cli/trace"
+)
+
+// archivedHistoryIterator pages through a workflow execution history allowing the frontend to fall back
+// to the archival store when the execution is no longer in the history service
+type archivedHistoryIterator struct {
+	c              *cli.Context
+	frontendClient workflowservice.WorkflowServiceClient
+	namespace      string
+	execution      *commonpb.WorkflowExecution
+
+	events        []*historypb.HistoryEvent
+	nextPageToken []byte
+	started       bool
+	err           error
+	// archived is true once a page has been served from the archival store
+	archived bool
+}
+
+func newArchivedHistoryIterator(c *cli.Context, namespace string, wid string, rid string) *archivedHistoryIterator {
+	return &archivedHistoryIterator{
+		c:              c,
+		frontendClient: cFactory.FrontendClient(c),
+		namespace:      namespace,
+		execution: &commonpb.WorkflowExecution{
+			WorkflowId: wid,
+			RunId:      rid,
+		},
+	}
+}
+
+func (h *archivedHistoryIterator) HasNext() bool {
+	if h.err != nil || len(h.events) > 0 {
+		return true
+	}
+	if h.started && len(h.nextPageToken) == 0 {
+		return false
+	}
+	h.fetch()
+	return h.err != nil || len(h.events) > 0
+}
+
+func (h *archivedHistoryIterator) Next() (*historypb.HistoryEvent, error) {
+	if h.err != nil {
+		err := h.err
+		h.err = nil
+		h.started, h.nextPageToken = true, nil
+		return nil, err
+	}
+	event := h.events[0]
+	h.events = h.events[1:]
+	return event, nil
+}
+
+func (h *archivedHistoryIterator) fetch() {
+	// archival stores can be slow, so use the same timeout as listing archived workflows
+	ctx, cancel := newContextWithTimeout(h.c, defaultContextTimeoutForListArchivedWorkflow)
+	defer cancel()
+	resp, err := h.frontendClient.GetWorkflowExecutionHistory(ctx, &workflowservice.GetWorkflowExecutionHistoryRequest{
+		Namespace:              h.namespace,
+		Execution:              h.execution,
+		NextPageToken:          h.nextPageToken,
+		HistoryEventFilterType: enumspb.HISTORY_EVENT_FILTER_TYPE_ALL_EVENT,
+		SkipArchival:           false,
+	})
+	h.started = true
+	if err != nil {
+		if _, ok := err.(*serviceerror.NotFound); ok {
+			h.err = fmt.Errorf("workflow execution not found in the history service or the archival store: %w", err)
+		} else {
+			h.err = fmt.Errorf("unable to get archived history: %w", err)
+		}
+		return
+	}
+	h.archived = h.archived || resp.GetArchived()
+	h.events = resp.GetHistory().GetEvents()
+	h.nextPageToken = resp.GetNextPageToken()
+}
+
+// checkHistoryArchivalEnabled returns an error if the namespace does not archive workflow histories
+func checkHistoryArchivalEnabled(c *cli.Context, namespace string) error {
+	ctx, cancel := newContext(c)
+	defer cancel()
+	resp, err := cFactory.FrontendClient(c).DescribeNamespace(ctx, &workflowservice.DescribeNamespaceRequest{
+		Namespace: namespace,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to describe namespace: %w", err)
+	}
+	if resp.GetConfig().GetHistoryArchivalState() != enumspb.ARCHIVAL_STATE_ENABLED {
+		return fmt.Errorf("namespace %s does not have history archival enabled (state: %s), archived histories are not available",
+			namespace, resp.GetConfig().GetHistoryArchivalState())
+	}
+	return nil
+}
+
+// archivedRunID returns the run id, which is required because the server only reads
+// from the archival store when the request names a run
+func archivedRunID(c *cli.Context) (string, error) {
+	rid := c.String(FlagRunID)
+	if rid == "" {
+		return "", fmt.Errorf("option %s is required with --%s, archived histories are looked up by run", FlagRunID, FlagArchive)
+	}
+	return rid, nil
+}
+
+// showArchivedHistory prints the history of an execution read through the frontend's archived history path
+func showArchivedHistory(c *cli.Context) error {
+	namespace, err := requiredFlag(c, FlagNamespace)
+	if err != nil {
+		return err
+	}
+	if c.Bool(output.FlagFollow) {
+		return fmt.Errorf("--%s can't be used with --%s", output.FlagFollow, FlagArchive)
+	}
+	rid, err := archivedRunID(c)
+	if err != nil {
+		return err
+	}
+	if err := checkHistoryArchivalEnabled(c, namespace); err != nil {
+		return err
+	}
+
+	hIter := newArchivedHistoryIterator(c, namespace, c.String(FlagWorkflowID), rid)
+	var lastEvent historypb.HistoryEvent
+	iter := &historyIterator{iter: hIter, maxFieldLength: c.Int(FlagMaxFieldLength), lastEvent: &lastEvent}
+	po := &output.PrintOptions{
+		Fields:     []string{"ID", "Time", "Type"},
+		FieldsLong: []string{"Details"},
+		Pager:      pager.Less,
+	}
+	if err := output.PrintIterator(c, iter, po); err != nil {
+		return err
+	}
+
+	if c.String(output.FlagOutput) != string(output.JSON) {
+		if !hIter.archived {
+			fmt.Println(color.Yellow(c, "\nExecution is still in the history service, history was not read from the archival store"))
+		}
+		fmt.Println(color.Magenta(c, "\nResult:"))
+		printRunStatus(c, &lastEvent)
+	}
+	return nil
+}
+
+// describeArchivedWorkflow rebuilds the execution info of an execution from its archived history
+func describeArchivedWorkflow(c *cli.Context) error {
+	namespace, err := requiredFlag(c, FlagNamespace)
+	if err != nil {
+		return err
+	}
+	rid, err := archivedRunID(c)
+	if err != nil {
+		return err
+	}
+	if err := checkHistoryArchivalEnabled(c, namespace); err != nil {
+		return err
+	}
+
+	wid := c.String(FlagWorkflowID)
+	hIter := newArchivedHistoryIterator(c, namespace, wid, rid)
+	state := trace.NewWorkflowExecutionState(wid, rid)
+	var started *historypb.WorkflowExecutionStartedEventAttributes
+	var historyLength int64
+	for hIter.HasNext() {
+		event, err := hIter.Next()
+		if err != nil {
+			return err
+		}
+		if attrs := event.GetWorkflowExecutionStartedEventAttributes(); attrs != nil {
+			started = attrs
+		}
+		state.Update(event)
+		historyLength++
+	}
+	state.IsArchived = hIter.archived
+
+	info := &workflowpb.WorkflowExecutionInfo{
+		Execution:        state.Execution,
+		Type:             state.Type,
+		StartTime:        state.StartTime,
+		CloseTime:        state.CloseTime,
+		Status:           state.Status,
+		HistoryLength:    historyLength,
+		TaskQueue:        started.GetTaskQueue().GetName(),
+		Memo:             started.GetMemo(),
+		SearchAttributes: started.GetSearchAttributes(),
+	}
+	if parent := started.GetParentWorkflowExecution(); parent != nil {
+		info.ParentExecution = parent
+		info.ParentNamespaceId = started.GetParentWorkflowNamespaceId()
+	}
+	resp := &workflowservice.DescribeWorkflowExecutionResponse{WorkflowExecutionInfo: info}
+
+	if !state.IsArchived {
+		fmt.Fprintln(os.Stderr, color.Yellow(c, "Execution is still in the history service, history was not read from the archival store"))
+	}
+	if c.Bool(FlagPrintRaw) {
+		prettyPrintJSONObject(resp)
+	} else {
+		prettyPrintJSONObject(convertDescribeWorkflowExecutionResponse(c, resp))
+	}
+	return nil
+}
@@ -0,0 +1,262 @@
+// The MIT License
+//
+// Copyright (c) 2022 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cli
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/temporalio/tctl-kit/pkg/color"
+	"github.com/temporalio/tctl-kit/pkg/output"
+	"github.com/urfave/cli/v2"
+)
+
+type benchSample struct {
+	startLatency    time.Duration
+	endToEndLatency time.Duration
+	startErr        error
+	runErr          error
+}
+
+type benchReport struct {
+	WorkflowType         string
+	TaskQueue            string
+	Workflows            int
+	Started              int
+	StartFailures        int
+	Completed            int `json:",omitempty"`
+	Failed               int `json:",omitempty"`
+	StartDuration        time.Duration
+	Duration             time.Duration
+	StartsPerSecond      float64
+	CompletionsPerSecond float64       `json:",omitempty"`
+	StartLatency         *latencyStats `json:",omitempty"`
+	EndToEndLatency      *latencyStats `json:",omitempty"`
+	FirstStartError      string        `json:",omitempty"`
+	FirstWorkflowError   string        `json:",omitempty"`
+}
+
+type latencyStats struct {
+	Latency string `json:"-"`
+	Count   int
+	Min     time.Duration
+	Mean    time.Duration
+	P50     time.Duration
+	P90     time.Duration
+	P99     time.Duration
+	Max     time.Duration
+}
+
+// BenchWorkflow starts a number of workflow executions and reports throughput and latency
+func BenchWorkflow(c *cli.Context) error {
+	sdkClient, err := getSDKClient(c)
+	if err != nil {
+		return err
+	}
+
+	wo, workflowType, err := startWorkflowOptions(c)
+	if err != nil {
+		return err
+	}
+	inputs, err := unmarshalInputsFromCLI(c)
+	if err != nil {
+		return err
+	}
+
+	count := c.Int(FlagCount)
+	if count < 1 {
+		return fmt.Errorf("--%s must be positive", FlagCount)
+	}
+	wait := c.Bool(FlagWait)
+	idPrefix := wo.ID
+
+	samples := make([]benchSample, count)
+	// waiting for completion happens outside of runConcurrently, so that the concurrency slot
+	// is free for the next start once the start request returns
+	var waiting sync.WaitGroup
+	begin := time.Now()
+	runConcurrently(count, c.Int(FlagConcurrency), c.Float64(FlagRPS), func(i int) {
+		sample := &samples[i]
+		options := wo
+		options.ID = fmt.Sprintf("%s-%d", idPrefix, i)
+
+		started := time.Now()
+		ctx, cancel := newContextForLongPoll(c)
+		run, err := sdkClient.ExecuteWorkflow(ctx, options, workflowType, inputs...)
+		cancel()
+		sample.startLatency = time.Since(started)
+		if err != nil {
+			sample.startErr = err
+			return
+		}
+
+		if wait {
+			waiting.Add(1)
+			go func() {
+				defer waiting.Done()
+				ctx, cancel := newIndefiniteContext(c)
+				defer cancel()
+				sample.runErr = run.Get(ctx, nil)
+				sample.endToEndLatency = time.Since(started)
+			}()
+		}
+	})
+	startElapsed := time.Since(begin)
+	waiting.Wait()
+
+	report := newBenchReport(samples, startElapsed, time.Since(begin), wait)
+	report.WorkflowType = workflowType
+	report.TaskQueue = wo.TaskQueue
+
+	if err := printBenchReport(c, report); err != nil {
+		return err
+	}
+	if report.StartFailures > 0 || report.Failed > 0 {
+		return fmt.Errorf("%d of %d workflows failed", report.StartFailures+report.Failed, report.Workflows)
+	}
+	return nil
+}
+
+// newBenchReport summarizes the samples. Start throughput is measured over startElapsed, the time it
+// took to start all workflows, and completion throughput over elapsed, which includes waiting for them.
+func newBenchReport(samples []benchSample, startElapsed time.Duration, elapsed time.Duration, wait bool) *benchReport {
+	report := &benchReport{
+		Workflows:     len(samples),
+		StartDuration: startElapsed.Round(time.Millisecond),
+		Duration:      elapsed.Round(time.Millisecond),
+	}
+
+	var startLatencies, endToEndLatencies []time.Duration
+	for _, s := range samples {
+		if s.startErr != nil {
+			report.StartFailures++
+			if report.FirstStartError == "" {
+				report.FirstStartError = s.startErr.Error()
+			}
+			continue
+		}
+		report.Started++
+		startLatencies = append(startLatencies, s.startLatency)
+
+		if !wait {
+			continue
+		}
+		if s.runErr != nil {
+			report.Failed++
+			if report.FirstWorkflowError == "" {
+				report.FirstWorkflowError = s.runErr.Error()
+			}
+			continue
+		}
+		report.Completed++
+		endToEndLatencies = append(endToEndLatencies, s.endToEndLatency)
+	}
+
+	if seconds := startElapsed.Seconds(); seconds > 0 {
+		report.StartsPerSecond = float64(report.Started) / seconds
+	}
+	if seconds := elapsed.Seconds(); seconds > 0 {
+		report.CompletionsPerSecond = float64(report.Completed) / seconds
+	}
+	report.StartLatency = newLatencyStats("start", startLatencies)
+	report.EndToEndLatency = newLatencyStats("end-to-end", endToEndLatencies)
+	return report
+}
+
+func newLatencyStats(name string, latencies []time.Duration) *latencyStats {
+	if len(latencies) == 0 {
+		return nil
+	}
+
+	sorted := append([]time.Duration(nil), latencies...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var total time.Duration
+	for _, l := range sorted {
+		total += l
+	}
+
+	return &latencyStats{
+		Latency: name,
+		Count:   len(sorted),
+		Min:     sorted[0],
+		Mean:    total / time.Duration(len(sorted)),
+		P50:     percentile(sorted, 50),
+		P90:     percentile(sorted, 90),
+		P99:     percentile(sorted, 99),
+		Max:     sorted[len(sorted)-1],
+	}
+}
+
+// percentile returns the nearest-rank percentile p of sorted latencies
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+func printBenchReport(c *cli.Context, report *benchReport) error {
+	if output.OutputOption(c.String(output.FlagOutput)) == output.JSON {
+		return output.PrintJSON(c, os.Stdout, report)
+	}
+
+	summary := []string{"WorkflowType", "TaskQueue", "Workflows", "Started", "StartFailures", "StartDuration", "Duration", "StartsPerSecond"}
+	if report.EndToEndLatency != nil || report.Failed > 0 {
+		summary = append(summary, "Completed", "Failed", "CompletionsPerSecond")
+	}
+	if report.FirstStartError != "" {
+		summary = append(summary, "FirstStartError")
+	}
+	if report.FirstWorkflowError != "" {
+		summary = append(summary, "FirstWorkflowError")
+	}
+	err := output.PrintItems(c, []interface{}{report}, &output.PrintOptions{
+		Fields:       summary,
+		OutputFormat: output.Card,
+	})
+	if err != nil {
+		return err
+	}
+
+	var latencies []interface{}
+	for _, l := range []*latencyStats{report.StartLatency, report.EndToEndLatency} {
+		if l != nil {
+			latencies = append(latencies, l)
+		}
+	}
+	if len(latencies) == 0 {
+		return nil
+	}
+	fmt.Println(color.Magenta(c, "\nLatency\n"))
+	return output.PrintItems(c, latencies, &output.PrintOptions{
+		Fields: []string{"Latency", "Count", "Min", "Mean", "P50", "P90", "P99", "Max"},
+	})
+}
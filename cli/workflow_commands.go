@@ -96,39 +96,13 @@ func StartWorkflow(c *cli.Context, printProgress bool) error {
 		return err
 	}
 
-	taskQueue, workflowType, et, rt, dt, wid := startWorkflowBaseArgs(c)
-
-	reusePolicy := defaultWorkflowIDReusePolicy
-	if c.IsSet(FlagWorkflowIDReusePolicy) {
-		reusePolicyInt, err := stringToEnum(c.String(FlagWorkflowIDReusePolicy), enumspb.WorkflowIdReusePolicy_value)
-		if err != nil {
-			return fmt.Errorf("unable to parse workflow ID reuse policy: %w", err)
-		}
-		reusePolicy = enumspb.WorkflowIdReusePolicy(reusePolicyInt)
-	}
-
-	inputs, err := unmarshalInputsFromCLI(c)
+	wo, workflowType, err := startWorkflowOptions(c)
 	if err != nil {
 		return err
 	}
+	wid, taskQueue := wo.ID, wo.TaskQueue
 
-	wo := sdkclient.StartWorkflowOptions{
-		ID:                       wid,
-		TaskQueue:                taskQueue,
-		WorkflowExecutionTimeout: time.Duration(et) * time.Second,
-		WorkflowTaskTimeout:      time.Duration(dt) * time.Second,
-		WorkflowRunTimeout:       time.Duration(rt) * time.Second,
-		WorkflowIDReusePolicy:    reusePolicy,
-	}
-	if c.IsSet(FlagCronSchedule) {
-		wo.CronSchedule = c.String(FlagCronSchedule)
-	}
-
-	wo.Memo, err = unmarshalMemoFromCLI(c)
-	if err != nil {
-		return err
-	}
-	wo.SearchAttributes, err = unmarshalSearchAttrFromCLI(c)
+	inputs, err := unmarshalInputsFromCLI(c)
 	if err != nil {
 		return err
 	}
@@ -179,6 +153,44 @@ func StartWorkflow(c *cli.Context, printProgress bool) error {
 	return nil
 }
 
+// startWorkflowOptions builds the options for starting a workflow from the start workflow flags
+func startWorkflowOptions(c *cli.Context) (sdkclient.StartWorkflowOptions, string, error) {
+	taskQueue, workflowType, et, rt, dt, wid := startWorkflowBaseArgs(c)
+
+	reusePolicy := defaultWorkflowIDReusePolicy
+	if c.IsSet(FlagWorkflowIDReusePolicy) {
+		reusePolicyInt, err := stringToEnum(c.String(FlagWorkflowIDReusePolicy), enumspb.WorkflowIdReusePolicy_value)
+		if err != nil {
+			return sdkclient.StartWorkflowOptions{}, "", fmt.Errorf("unable to parse workflow ID reuse policy: %w", err)
+		}
+		reusePolicy = enumspb.WorkflowIdReusePolicy(reusePolicyInt)
+	}
+
+	wo := sdkclient.StartWorkflowOptions{
+		ID:                       wid,
+		TaskQueue:                taskQueue,
+		WorkflowExecutionTimeout: time.Duration(et) * time.Second,
+		WorkflowTaskTimeout:      time.Duration(dt) * time.Second,
+		WorkflowRunTimeout:       time.Duration(rt) * time.Second,
+		WorkflowIDReusePolicy:    reusePolicy,
+	}
+	if c.IsSet(FlagCronSchedule) {
+		wo.CronSchedule = c.String(FlagCronSchedule)
+	}
+
+	var err error
+	wo.Memo, err = unmarshalMemoFromCLI(c)
+	if err != nil {
+		return sdkclient.StartWorkflowOptions{}, "", err
+	}
+	wo.SearchAttributes, err = unmarshalSearchAttrFromCLI(c)
+	if err != nil {
+		return sdkclient.StartWorkflowOptions{}, "", err
+	}
+
+	return wo, workflowType, nil
+}
+
 func formatInputsForDisplay(inputs []interface{}) string {
 	var result []string
 	for _, input := range inputs {
@@ -447,7 +459,12 @@ func cancelWorkflow(c *cli.Context) error {
 
 func SignalWorkflow(c *cli.Context) error {
 	if c.String(FlagQuery) != "" {
+		if c.IsSet(FlagInputFilePerExecution) {
+			return fmt.Errorf("--%s can't be used with --%s", FlagInputFilePerExecution, FlagQuery)
+		}
 		return BatchSignal(c)
+	} else if c.IsSet(FlagInputFilePerExecution) {
+		return signalWorkflowsFromFile(c)
 	} else {
 		return signalWorkflow(c)
 	}
@@ -467,7 +484,10 @@ func signalWorkflow(c *cli.Context) error {
 		return err
 	}
 	rid := c.String(FlagRunID)
-	name := c.String(FlagName)
+	name, err := requiredFlag(c, FlagName)
+	if err != nil {
+		return err
+	}
 	input, err := processJSONInput(c)
 	if err != nil {
 		return err
@@ -636,6 +656,13 @@ func CountWorkflow(c *cli.Context) error {
 
 // DescribeWorkflow show information about the specified workflow execution
 func DescribeWorkflow(c *cli.Context) error {
+	if c.Bool(FlagArchive) {
+		if c.Bool(FlagResetPointsOnly) {
+			return fmt.Errorf("--%s can't be used with --%s", FlagResetPointsOnly, FlagArchive)
+		}
+		return describeArchivedWorkflow(c)
+	}
+
 	wid := c.String(FlagWorkflowID)
 	rid := c.String(FlagRunID)
 
@@ -805,6 +832,10 @@ func printRunStatus(c *cli.Context, event *historypb.HistoryEvent) {
 
 // ShowHistory shows the history of given workflow execution based on workflowID and runID.
 func ShowHistory(c *cli.Context) error {
+	if c.Bool(FlagArchive) {
+		return showArchivedHistory(c)
+	}
+
 	wid := c.String(FlagWorkflowID)
 	rid := c.String(FlagRunID)
 
@@ -820,8 +851,9 @@ func ResetWorkflow(c *cli.Context) error {
 		return err
 	}
 	wid := c.String(FlagWorkflowID)
+	preview := c.Bool(FlagPreview)
 	reason := c.String(FlagReason)
-	if len(reason) == 0 {
+	if len(reason) == 0 && !preview {
 		return fmt.Errorf("reason flag cannot be empty")
 	}
 	rid := c.String(FlagRunID)
@@ -855,6 +887,9 @@ func ResetWorkflow(c *cli.Context) error {
 			return fmt.Errorf("getting reset event ID by type failed: %w", err)
 		}
 	}
+	if preview {
+		return previewReset(c, frontendClient, namespace, wid, resetBaseRunID, workflowTaskFinishID, resetReapplyTypesMap[resetReapplyType].(enumspb.ResetReapplyType))
+	}
 	resp, err := frontendClient.ResetWorkflowExecution(ctx, &workflowservice.ResetWorkflowExecutionRequest{
 		Namespace: namespace,
 		WorkflowExecution: &commonpb.WorkflowExecution{
@@ -1128,6 +1163,9 @@ func doReset(c *cli.Context, namespace, wid, rid string, params batchResetParams
 
 	if params.dryRun {
 		fmt.Printf("dry run to reset wid: %v, rid:%v to baseRunId:%v, eventId:%v \n", wid, rid, resetBaseRunID, workflowTaskFinishID)
+		if err := previewReset(c, frontendClient, namespace, wid, resetBaseRunID, workflowTaskFinishID, enumspb.RESET_REAPPLY_TYPE_UNSPECIFIED); err != nil {
+			return printErrorAndReturn("reset preview failed", err)
+		}
 	} else {
 		resp2, err := frontendClient.ResetWorkflowExecution(ctx, &workflowservice.ResetWorkflowExecutionRequest{
 			Namespace: namespace,
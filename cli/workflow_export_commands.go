@@ -0,0 +1,369 @@
+// The MIT License
+//
+// Copyright (c) 2022 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cli
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/temporalio/tctl-kit/pkg/color"
+	"github.com/urfave/cli/v2"
+	commonpb "go.temporal.io/api/common/v1"
+	historypb "go.temporal.io/api/history/v1"
+	workflowpb "go.temporal.io/api/workflow/v1"
+	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/server/common/backoff"
+)
+
+const (
+	exportManifestFileName     = "manifest.ndjson"
+	exportHistoriesDirName     = "histories"
+	exportRetryInitialInterval = 200 * time.Millisecond
+	exportRetryMaxAttempts     = 5
+	exportTmpFileSuffix        = ".tmp"
+	// exportMaxFileWorkflowIDLength keeps history file names within the 255 byte limit of common file systems
+	exportMaxFileWorkflowIDLength = 100
+)
+
+// exportManifestEntry is a single line of manifest.ndjson. Execution holds the execution info,
+// including memo and search attributes, as returned by visibility.
+type exportManifestEntry struct {
+	WorkflowID  string          `json:"workflowId"`
+	RunID       string          `json:"runId"`
+	HistoryFile string          `json:"historyFile"`
+	Events      int             `json:"events"`
+	ExportTime  time.Time       `json:"exportTime"`
+	Execution   json.RawMessage `json:"execution"`
+}
+
+// ExportWorkflow writes the histories of all workflow executions matching a query to a local directory
+func ExportWorkflow(c *cli.Context) error {
+	namespace, err := requiredFlag(c, FlagNamespace)
+	if err != nil {
+		return err
+	}
+	dir, err := requiredFlag(c, FlagDir)
+	if err != nil {
+		return err
+	}
+	sdkClient, err := getSDKClient(c)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Join(dir, exportHistoriesDirName), 0755); err != nil {
+		return fmt.Errorf("unable to create export directory: %w", err)
+	}
+	manifestPath := filepath.Join(dir, exportManifestFileName)
+	exported, err := readExportManifest(manifestPath)
+	if err != nil {
+		return err
+	}
+	manifest, err := os.OpenFile(manifestPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("unable to open manifest: %w", err)
+	}
+	defer manifest.Close()
+	if len(exported) > 0 {
+		fmt.Println(color.Yellow(c, "Resuming export, %d executions already exported", len(exported)))
+	}
+
+	frontendClient := cFactory.FrontendClient(c)
+	var manifestLock sync.Mutex
+	var written, skipped, failed int32
+	var failures []string
+
+	query := c.String(FlagQuery)
+	var npt []byte
+	for {
+		var items []interface{}
+		items, npt, err = listWorkflows(c, sdkClient, npt, query)
+		if err != nil {
+			return err
+		}
+
+		var pending []*workflowpb.WorkflowExecutionInfo
+		for _, item := range items {
+			info := item.(*workflowpb.WorkflowExecutionInfo)
+			if exported[exportKey(info.GetExecution())] {
+				skipped++
+				continue
+			}
+			pending = append(pending, info)
+		}
+
+		runConcurrently(len(pending), c.Int(FlagConcurrency), c.Float64(FlagRPS), func(i int) {
+			info := pending[i]
+			entry, err := exportExecution(c, frontendClient, namespace, dir, info)
+			if err == nil {
+				manifestLock.Lock()
+				err = writeExportManifestEntry(manifest, entry)
+				manifestLock.Unlock()
+			}
+			if err != nil {
+				manifestLock.Lock()
+				failures = append(failures, fmt.Sprintf("%s/%s: %v", info.GetExecution().GetWorkflowId(), info.GetExecution().GetRunId(), err))
+				manifestLock.Unlock()
+				atomic.AddInt32(&failed, 1)
+				return
+			}
+			atomic.AddInt32(&written, 1)
+		})
+
+		if len(npt) == 0 {
+			break
+		}
+	}
+
+	for _, f := range failures {
+		fmt.Println(color.Red(c, "Failed to export %s", f))
+	}
+	fmt.Println(color.Green(c, "Exported %d executions to %s (%d already exported, %d failed)", written, dir, skipped, failed))
+
+	if c.IsSet(FlagBundle) {
+		if err := writeTarGz(dir, c.String(FlagBundle)); err != nil {
+			return err
+		}
+		fmt.Println(color.Green(c, "Wrote bundle %s", c.String(FlagBundle)))
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d executions failed to export, run the command again to resume", failed)
+	}
+	return nil
+}
+
+// exportExecution downloads the full history of an execution and writes it as a protojson file
+func exportExecution(c *cli.Context, frontendClient workflowservice.WorkflowServiceClient, namespace string, dir string, info *workflowpb.WorkflowExecutionInfo) (*exportManifestEntry, error) {
+	history, err := getFullHistory(c, frontendClient, namespace, info.GetExecution())
+	if err != nil {
+		return nil, fmt.Errorf("unable to get history: %w", err)
+	}
+
+	execution := info.GetExecution()
+	historyFile := filepath.Join(exportHistoriesDirName, exportHistoryFileName(execution))
+	// write to a temporary file first so that an interrupted export never leaves a truncated history behind
+	tmpPath := filepath.Join(dir, historyFile+exportTmpFileSuffix)
+	file, err := os.Create(tmpPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create history file: %w", err)
+	}
+	marshaler := jsonpb.Marshaler{Indent: "  "}
+	err = marshaler.Marshal(file, history)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to write history file: %w", err)
+	}
+	if err := os.Rename(tmpPath, filepath.Join(dir, historyFile)); err != nil {
+		return nil, fmt.Errorf("unable to write history file: %w", err)
+	}
+
+	executionJSON, err := (&jsonpb.Marshaler{}).MarshalToString(info)
+	if err != nil {
+		return nil, fmt.Errorf("unable to serialize execution info: %w", err)
+	}
+
+	return &exportManifestEntry{
+		WorkflowID:  execution.GetWorkflowId(),
+		RunID:       execution.GetRunId(),
+		HistoryFile: filepath.ToSlash(historyFile),
+		Events:      len(history.GetEvents()),
+		ExportTime:  time.Now().UTC(),
+		Execution:   json.RawMessage(executionJSON),
+	}, nil
+}
+
+// exportHistoryFileName returns the name of the history file of an execution. Long workflow IDs are
+// truncated and suffixed with their hash, the manifest keeps the original ID.
+func exportHistoryFileName(execution *commonpb.WorkflowExecution) string {
+	workflowID := url.PathEscape(execution.GetWorkflowId())
+	if len(workflowID) > exportMaxFileWorkflowIDLength {
+		workflowID = fmt.Sprintf("%s-%x", workflowID[:exportMaxFileWorkflowIDLength], sha256.Sum256([]byte(execution.GetWorkflowId())))
+	}
+	return fmt.Sprintf("%s_%s.json", workflowID, execution.GetRunId())
+}
+
+// getFullHistory reads all pages of an execution's history, retrying transient errors per page
+func getFullHistory(c *cli.Context, frontendClient workflowservice.WorkflowServiceClient, namespace string, execution *commonpb.WorkflowExecution) (*historypb.History, error) {
+	retryPolicy := backoff.NewExponentialRetryPolicy(exportRetryInitialInterval).
+		WithMaximumAttempts(exportRetryMaxAttempts)
+
+	history := &historypb.History{}
+	var npt []byte
+	for {
+		var resp *workflowservice.GetWorkflowExecutionHistoryResponse
+		op := func() error {
+			ctx, cancel := newContext(c)
+			defer cancel()
+			var err error
+			resp, err = frontendClient.GetWorkflowExecutionHistory(ctx, &workflowservice.GetWorkflowExecutionHistoryRequest{
+				Namespace:     namespace,
+				Execution:     execution,
+				NextPageToken: npt,
+			})
+			return err
+		}
+		if err := backoff.ThrottleRetry(op, retryPolicy, isTransientError); err != nil {
+			return nil, err
+		}
+
+		history.Events = append(history.Events, resp.GetHistory().GetEvents()...)
+		npt = resp.GetNextPageToken()
+		if len(npt) == 0 {
+			return history, nil
+		}
+	}
+}
+
+func exportKey(execution *commonpb.WorkflowExecution) string {
+	return execution.GetWorkflowId() + "/" + execution.GetRunId()
+}
+
+// readExportManifest returns the executions already recorded in the manifest of a previous export.
+// A partially written last line from an interrupted export is cut off, so that new entries are not
+// appended to it.
+func readExportManifest(path string) (map[string]bool, error) {
+	exported := make(map[string]bool)
+	// This code is only used in the CLI. The input provided is from a trusted user.
+	// #nosec
+	file, err := os.OpenFile(path, os.O_RDWR, 0644)
+	if os.IsNotExist(err) {
+		return exported, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to open manifest: %w", err)
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	var complete int64
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to read manifest: %w", err)
+		}
+		complete += int64(len(line))
+		var entry exportManifestEntry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			continue
+		}
+		exported[exportKey(&commonpb.WorkflowExecution{WorkflowId: entry.WorkflowID, RunId: entry.RunID})] = true
+	}
+	if err := file.Truncate(complete); err != nil {
+		return nil, fmt.Errorf("unable to truncate manifest: %w", err)
+	}
+	return exported, nil
+}
+
+func writeExportManifestEntry(w io.Writer, entry *exportManifestEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("unable to serialize manifest entry: %w", err)
+	}
+	if _, err := w.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("unable to write manifest: %w", err)
+	}
+	return nil
+}
+
+// writeTarGz bundles the contents of dir into a gzipped tarball at path
+func writeTarGz(dir string, path string) error {
+	bundlePath, err := filepath.Abs(path)
+	if err != nil {
+		return fmt.Errorf("unable to create bundle: %w", err)
+	}
+	file, err := os.Create(bundlePath)
+	if err != nil {
+		return fmt.Errorf("unable to create bundle: %w", err)
+	}
+	defer file.Close()
+
+	gw := gzip.NewWriter(file)
+	tw := tar.NewWriter(gw)
+	root := filepath.Base(filepath.Clean(dir))
+	err = filepath.Walk(dir, func(path string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if abs, _ := filepath.Abs(path); abs == bundlePath {
+			return nil
+		}
+		// left behind by an interrupted export
+		if fi.Mode().IsRegular() && strings.HasSuffix(path, exportTmpFileSuffix) {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		header, err := tar.FileInfoHeader(fi, "")
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(filepath.Join(root, rel))
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if !fi.Mode().IsRegular() {
+			return nil
+		}
+		// #nosec
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(tw, f)
+		return err
+	})
+	if err == nil {
+		err = tw.Close()
+	}
+	if err == nil {
+		err = gw.Close()
+	}
+	if err != nil {
+		return fmt.Errorf("unable to write bundle: %w", err)
+	}
+	return file.Close()
+}
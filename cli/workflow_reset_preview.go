@@ -0,0 +1,243 @@
+// The MIT License
+//
+// Copyright (c) 2022 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cli
+
+import (
+	"fmt"
+
+	"github.com/temporalio/tctl-kit/pkg/color"
+	"github.com/temporalio/tctl-kit/pkg/output"
+	"github.com/urfave/cli/v2"
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	historypb "go.temporal.io/api/history/v1"
+	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/server/common/primitives/timestamp"
+)
+
+type resetPreviewEvent struct {
+	ID      int64
+	Type    string
+	Details string
+}
+
+// resetPreview describes what resetting a run to a workflow task finish event does to its history
+type resetPreview struct {
+	ResetEventID int64
+	ReapplyType  enumspb.ResetReapplyType
+	// Discarded are the events after the reset point that are not carried over to the new run
+	Discarded []resetPreviewEvent
+	// Reapplied are the events after the reset point that are reapplied to the new run
+	Reapplied []resetPreviewEvent
+	Warnings  []string
+}
+
+// previewReset loads the history of the base run and prints what a reset to resetEventID would do
+func previewReset(c *cli.Context, frontendClient workflowservice.WorkflowServiceClient, namespace, wid, rid string, resetEventID int64, reapplyType enumspb.ResetReapplyType) error {
+	history, err := getFullHistory(c, frontendClient, namespace, &commonpb.WorkflowExecution{
+		WorkflowId: wid,
+		RunId:      rid,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to get history: %w", err)
+	}
+
+	preview, err := computeResetPreview(history.GetEvents(), resetEventID, reapplyType)
+	if err != nil {
+		return err
+	}
+	return printResetPreview(c, wid, rid, preview)
+}
+
+// computeResetPreview works out which events after the reset point are discarded or reapplied, and which
+// side effects will happen again once the new run replays from the reset point
+func computeResetPreview(events []*historypb.HistoryEvent, resetEventID int64, reapplyType enumspb.ResetReapplyType) (*resetPreview, error) {
+	if reapplyType == enumspb.RESET_REAPPLY_TYPE_UNSPECIFIED {
+		// the server reapplies signals by default
+		reapplyType = enumspb.RESET_REAPPLY_TYPE_SIGNAL
+	}
+
+	var resetPointValid bool
+	activityTypes := make(map[int64]string)
+	childWorkflows := make(map[int64]string)
+	for _, e := range events {
+		if e.GetEventId() == resetEventID-1 && e.GetEventType() == enumspb.EVENT_TYPE_WORKFLOW_TASK_STARTED {
+			resetPointValid = true
+		}
+		if attrs := e.GetActivityTaskScheduledEventAttributes(); attrs != nil {
+			activityTypes[e.GetEventId()] = attrs.GetActivityType().GetName()
+		}
+		if attrs := e.GetStartChildWorkflowExecutionInitiatedEventAttributes(); attrs != nil {
+			childWorkflows[e.GetEventId()] = fmt.Sprintf("%s (%s)", attrs.GetWorkflowId(), attrs.GetWorkflowType().GetName())
+		}
+	}
+	if !resetPointValid {
+		return nil, fmt.Errorf("event %d is not a valid reset point, it must directly follow a WorkflowTaskStarted event", resetEventID)
+	}
+
+	preview := &resetPreview{
+		ResetEventID: resetEventID,
+		ReapplyType:  reapplyType,
+	}
+	rerunActivities := make(map[int64]bool)
+	for _, e := range events {
+		if e.GetEventId() < resetEventID {
+			continue
+		}
+		row := resetPreviewEvent{
+			ID:      e.GetEventId(),
+			Type:    e.GetEventType().String(),
+			Details: resetPreviewDetails(e, activityTypes, childWorkflows),
+		}
+
+		switch e.GetEventType() {
+		case enumspb.EVENT_TYPE_WORKFLOW_TASK_SCHEDULED,
+			enumspb.EVENT_TYPE_WORKFLOW_TASK_STARTED,
+			enumspb.EVENT_TYPE_WORKFLOW_TASK_COMPLETED,
+			enumspb.EVENT_TYPE_WORKFLOW_TASK_FAILED,
+			enumspb.EVENT_TYPE_WORKFLOW_TASK_TIMED_OUT:
+			// workflow task bookkeeping is regenerated by the new run
+			continue
+		case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_SIGNALED:
+			if reapplyType == enumspb.RESET_REAPPLY_TYPE_SIGNAL {
+				preview.Reapplied = append(preview.Reapplied, row)
+				continue
+			}
+		case enumspb.EVENT_TYPE_ACTIVITY_TASK_STARTED,
+			enumspb.EVENT_TYPE_ACTIVITY_TASK_COMPLETED,
+			enumspb.EVENT_TYPE_ACTIVITY_TASK_FAILED,
+			enumspb.EVENT_TYPE_ACTIVITY_TASK_TIMED_OUT:
+			scheduledID := activityScheduledEventID(e)
+			if !rerunActivities[scheduledID] {
+				rerunActivities[scheduledID] = true
+				preview.Warnings = append(preview.Warnings, fmt.Sprintf("activity %s (scheduled event %d) ran after the reset point and will be executed again",
+					activityTypes[scheduledID], scheduledID))
+			}
+		case enumspb.EVENT_TYPE_CHILD_WORKFLOW_EXECUTION_STARTED:
+			initiatedID := e.GetChildWorkflowExecutionStartedEventAttributes().GetInitiatedEventId()
+			preview.Warnings = append(preview.Warnings, fmt.Sprintf("child workflow %s was started after the reset point, it is not terminated by the reset and will be started again",
+				childWorkflows[initiatedID]))
+		case enumspb.EVENT_TYPE_SIGNAL_EXTERNAL_WORKFLOW_EXECUTION_INITIATED:
+			preview.Warnings = append(preview.Warnings, fmt.Sprintf("signal %s to workflow %s will be sent again", row.Details,
+				e.GetSignalExternalWorkflowExecutionInitiatedEventAttributes().GetWorkflowExecution().GetWorkflowId()))
+		case enumspb.EVENT_TYPE_REQUEST_CANCEL_EXTERNAL_WORKFLOW_EXECUTION_INITIATED:
+			preview.Warnings = append(preview.Warnings, fmt.Sprintf("cancellation of workflow %s will be requested again", row.Details))
+		case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_UPDATE_ACCEPTED:
+			preview.Warnings = append(preview.Warnings, fmt.Sprintf("update accepted at event %d is not reapplied by reset", e.GetEventId()))
+		}
+		preview.Discarded = append(preview.Discarded, row)
+	}
+
+	return preview, nil
+}
+
+func activityScheduledEventID(e *historypb.HistoryEvent) int64 {
+	switch e.GetEventType() {
+	case enumspb.EVENT_TYPE_ACTIVITY_TASK_STARTED:
+		return e.GetActivityTaskStartedEventAttributes().GetScheduledEventId()
+	case enumspb.EVENT_TYPE_ACTIVITY_TASK_COMPLETED:
+		return e.GetActivityTaskCompletedEventAttributes().GetScheduledEventId()
+	case enumspb.EVENT_TYPE_ACTIVITY_TASK_FAILED:
+		return e.GetActivityTaskFailedEventAttributes().GetScheduledEventId()
+	case enumspb.EVENT_TYPE_ACTIVITY_TASK_TIMED_OUT:
+		return e.GetActivityTaskTimedOutEventAttributes().GetScheduledEventId()
+	case enumspb.EVENT_TYPE_ACTIVITY_TASK_CANCELED:
+		return e.GetActivityTaskCanceledEventAttributes().GetScheduledEventId()
+	}
+	return 0
+}
+
+// resetPreviewDetails gives a short description of the event, e.g. the activity type or signal name
+func resetPreviewDetails(e *historypb.HistoryEvent, activityTypes map[int64]string, childWorkflows map[int64]string) string {
+	switch e.GetEventType() {
+	case enumspb.EVENT_TYPE_ACTIVITY_TASK_SCHEDULED:
+		attrs := e.GetActivityTaskScheduledEventAttributes()
+		return fmt.Sprintf("%s (activity id %s)", attrs.GetActivityType().GetName(), attrs.GetActivityId())
+	case enumspb.EVENT_TYPE_ACTIVITY_TASK_STARTED,
+		enumspb.EVENT_TYPE_ACTIVITY_TASK_COMPLETED,
+		enumspb.EVENT_TYPE_ACTIVITY_TASK_FAILED,
+		enumspb.EVENT_TYPE_ACTIVITY_TASK_TIMED_OUT,
+		enumspb.EVENT_TYPE_ACTIVITY_TASK_CANCELED:
+		return activityTypes[activityScheduledEventID(e)]
+	case enumspb.EVENT_TYPE_TIMER_STARTED:
+		attrs := e.GetTimerStartedEventAttributes()
+		return fmt.Sprintf("%s (%s)", attrs.GetTimerId(), timestamp.DurationValue(attrs.GetStartToFireTimeout()))
+	case enumspb.EVENT_TYPE_TIMER_FIRED:
+		return e.GetTimerFiredEventAttributes().GetTimerId()
+	case enumspb.EVENT_TYPE_START_CHILD_WORKFLOW_EXECUTION_INITIATED:
+		return childWorkflows[e.GetEventId()]
+	case enumspb.EVENT_TYPE_CHILD_WORKFLOW_EXECUTION_STARTED:
+		return childWorkflows[e.GetChildWorkflowExecutionStartedEventAttributes().GetInitiatedEventId()]
+	case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_SIGNALED:
+		return e.GetWorkflowExecutionSignaledEventAttributes().GetSignalName()
+	case enumspb.EVENT_TYPE_SIGNAL_EXTERNAL_WORKFLOW_EXECUTION_INITIATED:
+		return e.GetSignalExternalWorkflowExecutionInitiatedEventAttributes().GetSignalName()
+	case enumspb.EVENT_TYPE_REQUEST_CANCEL_EXTERNAL_WORKFLOW_EXECUTION_INITIATED:
+		return e.GetRequestCancelExternalWorkflowExecutionInitiatedEventAttributes().GetWorkflowExecution().GetWorkflowId()
+	case enumspb.EVENT_TYPE_MARKER_RECORDED:
+		return e.GetMarkerRecordedEventAttributes().GetMarkerName()
+	}
+	return ""
+}
+
+func printResetPreview(c *cli.Context, wid, rid string, preview *resetPreview) error {
+	fmt.Println(color.Magenta(c, "Reset preview for workflow %s run %s to event %d (reapply: %s)",
+		wid, rid, preview.ResetEventID, preview.ReapplyType))
+
+	opts := &output.PrintOptions{
+		Fields: []string{"ID", "Type", "Details"},
+	}
+	if len(preview.Discarded) == 0 {
+		fmt.Println("\nNo events after the reset point will be discarded")
+	} else {
+		fmt.Println(color.Magenta(c, "\nDiscarded events\n"))
+		items := make([]interface{}, len(preview.Discarded))
+		for i, e := range preview.Discarded {
+			items[i] = e
+		}
+		if err := output.PrintItems(c, items, opts); err != nil {
+			return err
+		}
+	}
+
+	if len(preview.Reapplied) > 0 {
+		fmt.Println(color.Magenta(c, "\nReapplied events\n"))
+		items := make([]interface{}, len(preview.Reapplied))
+		for i, e := range preview.Reapplied {
+			items[i] = e
+		}
+		if err := output.PrintItems(c, items, opts); err != nil {
+			return err
+		}
+	}
+
+	if len(preview.Warnings) > 0 {
+		fmt.Println()
+	}
+	for _, w := range preview.Warnings {
+		fmt.Println(color.Yellow(c, "WARNING: %s", w))
+	}
+	return nil
+}
@@ -0,0 +1,225 @@
+// The MIT License
+//
+// Copyright (c) 2022 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"github.com/pborman/uuid"
+	"github.com/temporalio/tctl-kit/pkg/color"
+	"github.com/temporalio/tctl-kit/pkg/output"
+	"github.com/urfave/cli/v2"
+	commonpb "go.temporal.io/api/common/v1"
+	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/server/common/backoff"
+)
+
+const (
+	signalRetryInitialInterval = 200 * time.Millisecond
+	signalRetryMaxAttempts     = 5
+)
+
+// signalFileEntry is a single line of the --input-file-per-execution file
+type signalFileEntry struct {
+	WorkflowID string          `json:"workflowId"`
+	RunID      string          `json:"runId"`
+	Name       string          `json:"name"`
+	Input      json.RawMessage `json:"input"`
+
+	line int
+}
+
+type signalResult struct {
+	Line       int
+	WorkflowId string
+	RunId      string
+	Signal     string
+	Status     string
+	Attempts   int
+	Error      string
+}
+
+// signalWorkflowsFromFile signals every execution listed in the --input-file-per-execution file,
+// each with its own signal name and input
+func signalWorkflowsFromFile(c *cli.Context) error {
+	namespace, err := requiredFlag(c, FlagNamespace)
+	if err != nil {
+		return err
+	}
+	if isRawPayloadInput(c) {
+		return fmt.Errorf("--%s, --%s and --%s can't be used with --%s, the inputs of the file are encoded as JSON",
+			FlagInputEncoding, FlagInputMeta, FlagInputPayload, FlagInputFilePerExecution)
+	}
+
+	entries, err := readSignalFile(c.String(FlagInputFilePerExecution), c.String(FlagName))
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		fmt.Println(color.Yellow(c, "No signals to send"))
+		return nil
+	}
+
+	promptMsg := fmt.Sprintf(
+		"Will send %v signals. Continue? Y/N",
+		color.Yellow(c, "%v", len(entries)),
+	)
+	if !promptYes(promptMsg, c.Bool(FlagYes)) {
+		return nil
+	}
+
+	serviceClient := cFactory.FrontendClient(c)
+	identity := getCliIdentity()
+	retryPolicy := backoff.NewExponentialRetryPolicy(signalRetryInitialInterval).
+		WithMaximumAttempts(signalRetryMaxAttempts)
+
+	results := make([]signalResult, len(entries))
+	var failed int32
+	runConcurrently(len(entries), c.Int(FlagConcurrency), c.Float64(FlagRPS), func(i int) {
+		entry := entries[i]
+		result := signalResult{
+			Line:       entry.line,
+			WorkflowId: entry.WorkflowID,
+			RunId:      entry.RunID,
+			Signal:     entry.Name,
+		}
+
+		input, err := encodeSignalFileInput(entry.Input)
+		if err == nil {
+			request := &workflowservice.SignalWorkflowExecutionRequest{
+				Namespace: namespace,
+				WorkflowExecution: &commonpb.WorkflowExecution{
+					WorkflowId: entry.WorkflowID,
+					RunId:      entry.RunID,
+				},
+				SignalName: entry.Name,
+				Input:      input,
+				Identity:   identity,
+				// the same request id is reused by retries so that the server can dedup the signal
+				RequestId: uuid.New(),
+			}
+			op := func() error {
+				result.Attempts++
+				ctx, cancel := newContext(c)
+				defer cancel()
+				_, err := serviceClient.SignalWorkflowExecution(ctx, request)
+				return err
+			}
+			err = backoff.ThrottleRetry(op, retryPolicy, isTransientError)
+		}
+
+		if err != nil {
+			atomic.AddInt32(&failed, 1)
+			result.Status = "FAILED"
+			result.Error = err.Error()
+		} else {
+			result.Status = "OK"
+		}
+		results[i] = result
+	})
+
+	items := make([]interface{}, len(results))
+	for i, r := range results {
+		items[i] = r
+	}
+	opts := &output.PrintOptions{
+		Fields: []string{"Line", "WorkflowId", "RunId", "Signal", "Status", "Attempts", "Error"},
+	}
+	if err := output.PrintItems(c, items, opts); err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d signals failed", failed, len(entries))
+	}
+	fmt.Println(color.Green(c, "Sent %d signals", len(entries)))
+	return nil
+}
+
+// readSignalFile parses a newline delimited JSON file of signals. Every line is validated before any signal is sent.
+func readSignalFile(fileName string, defaultName string) ([]signalFileEntry, error) {
+	// This code is only used in the CLI. The input provided is from a trusted user.
+	// #nosec
+	file, err := os.Open(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open input file: %w", err)
+	}
+	defer file.Close()
+
+	var entries []signalFileEntry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), 16*1024*1024)
+	idx := 0
+	for scanner.Scan() {
+		idx++
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		var entry signalFileEntry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			return nil, fmt.Errorf("line %d is not valid JSON: %w", idx, err)
+		}
+		if entry.WorkflowID == "" {
+			return nil, fmt.Errorf("line %d: workflowId is required", idx)
+		}
+		if entry.Name == "" {
+			entry.Name = defaultName
+		}
+		if entry.Name == "" {
+			return nil, fmt.Errorf("line %d: signal name is required, set it in the line or with --%s", idx, FlagName)
+		}
+		entry.line = idx
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("unable to read input file: %w", err)
+	}
+
+	return entries, nil
+}
+
+func encodeSignalFileInput(raw json.RawMessage) (*commonpb.Payloads, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+
+	var input interface{}
+	if err := json.Unmarshal(raw, &input); err != nil {
+		return nil, fmt.Errorf("input is not valid JSON: %w", err)
+	}
+	p, err := customDataConverter().ToPayloads(input)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode input: %w", err)
+	}
+	return p, nil
+}
@@ -25,7 +25,17 @@
 package cli
 
 import (
+	"archive/tar"
+	"compress/gzip"
 	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/golang/mock/gomock"
@@ -33,12 +43,19 @@ import (
 	"github.com/stretchr/testify/mock"
 	commonpb "go.temporal.io/api/common/v1"
 	enumspb "go.temporal.io/api/enums/v1"
+	historypb "go.temporal.io/api/history/v1"
+	namespacepb "go.temporal.io/api/namespace/v1"
 	"go.temporal.io/api/serviceerror"
+	taskqueuepb "go.temporal.io/api/taskqueue/v1"
 	workflowpb "go.temporal.io/api/workflow/v1"
 	"go.temporal.io/api/workflowservice/v1"
 	sdkclient "go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/converter"
+	sdkmocks "go.temporal.io/sdk/mocks"
 	"go.temporal.io/server/common/payloads"
 	"go.temporal.io/server/common/primitives/timestamp"
+
+	"github.com/temporalio/tctl/cli/dataconverter"
 )
 
 func (s *cliAppSuite) TestShowHistory() {
@@ -171,12 +188,465 @@ func (s *cliAppSuite) TestSignalWorkflow() {
 	s.Nil(err)
 }
 
+func (s *cliAppSuite) TestSignalWorkflow_ProtobufInput() {
+	s.frontendClient.EXPECT().SignalWorkflowExecution(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, req *workflowservice.SignalWorkflowExecutionRequest, _ ...interface{}) (*workflowservice.SignalWorkflowExecutionResponse, error) {
+			payload := req.GetInput().GetPayloads()[0]
+			s.Equal("json/protobuf", string(payload.GetMetadata()["encoding"]))
+			s.Equal("orders.v1.Approval", string(payload.GetMetadata()["messageType"]))
+			s.Equal(`{"approved":true}`, string(payload.GetData()))
+			return &workflowservice.SignalWorkflowExecutionResponse{}, nil
+		})
+	err := s.app.Run([]string{"", "--namespace", cliTestNamespace, "workflow", "signal", "--name", "signal-name", "--workflow-id", "wid",
+		"--input", `{"approved":true}`, "--input-encoding", "json/protobuf", "--input-meta", "messageType=orders.v1.Approval"})
+	s.Nil(err)
+}
+
+func (s *cliAppSuite) TestSignalWorkflow_ProtobufInputWithoutMessageType() {
+	errorCode := s.RunWithExitCode([]string{"", "--namespace", cliTestNamespace, "workflow", "signal", "--name", "signal-name", "--workflow-id", "wid",
+		"--input", `{"approved":true}`, "--input-encoding", "json/protobuf"})
+	s.Equal(1, errorCode)
+}
+
+func (s *cliAppSuite) TestSignalWorkflow_PayloadInput() {
+	s.frontendClient.EXPECT().SignalWorkflowExecution(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, req *workflowservice.SignalWorkflowExecutionRequest, _ ...interface{}) (*workflowservice.SignalWorkflowExecutionResponse, error) {
+			payload := req.GetInput().GetPayloads()[0]
+			s.Equal("binary/plain", string(payload.GetMetadata()["encoding"]))
+			s.Equal("raw bytes", string(payload.GetData()))
+			return &workflowservice.SignalWorkflowExecutionResponse{}, nil
+		})
+	// {"metadata": {"encoding": "binary/plain"}, "data": "raw bytes"}
+	err := s.app.Run([]string{"", "--namespace", cliTestNamespace, "workflow", "signal", "--name", "signal-name", "--workflow-id", "wid",
+		"--input", `{"metadata": {"encoding": "YmluYXJ5L3BsYWlu"}, "data": "cmF3IGJ5dGVz"}`, "--input-payload"})
+	s.Nil(err)
+}
+
+func (s *cliAppSuite) TestPayloadInputFlags_OnlyOnCommandsThatHonorThem() {
+	hasFlag := func(group, name string) bool {
+		for _, cmd := range s.app.Command(group).Subcommands {
+			if cmd.Name != name {
+				continue
+			}
+			for _, f := range cmd.Flags {
+				if f.Names()[0] == FlagInputEncoding {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	s.True(hasFlag("workflow", "start"))
+	s.True(hasFlag("workflow", "query"))
+	s.True(hasFlag("workflow", "signal"))
+	s.False(hasFlag("workflow", "stack"))
+	s.False(hasFlag("schedule", "create"))
+	s.False(hasFlag("schedule", "update"))
+}
+
+func (s *cliAppSuite) TestSignalWorkflow_PayloadInputWithPluginConverter() {
+	// converters set with SetCurrent, such as plugins, would encode raw payloads without their codec
+	previous := dataconverter.GetCurrent()
+	defer dataconverter.SetCurrent(previous)
+	dataconverter.SetCurrent(converter.GetDefaultDataConverter())
+
+	errorCode := s.RunWithExitCode([]string{"", "--namespace", cliTestNamespace, "workflow", "signal", "--name", "signal-name", "--workflow-id", "wid",
+		"--input", "raw bytes", "--input-encoding", "binary/plain"})
+	s.Equal(1, errorCode)
+	s.False(dataconverter.AcceptsRawPayloads())
+}
+
+func (s *cliAppSuite) TestSignalWorkflow_PayloadInputWithInputFile() {
+	errorCode := s.RunWithExitCode([]string{"", "--namespace", cliTestNamespace, "workflow", "signal", "--name", "signal-name",
+		"--input-file-per-execution", "signals.ndjson", "--input-encoding", "binary/plain"})
+	s.Equal(1, errorCode)
+}
+
 func (s *cliAppSuite) TestSignalWorkflow_Failed() {
 	s.frontendClient.EXPECT().SignalWorkflowExecution(gomock.Any(), gomock.Any()).Return(nil, serviceerror.NewInvalidArgument("faked error"))
 	errorCode := s.RunWithExitCode([]string{"", "--namespace", cliTestNamespace, "workflow", "signal", "--name", "signal-name", "--workflow-id", "wid"})
 	s.Equal(1, errorCode)
 }
 
+func (s *cliAppSuite) TestSignalWorkflow_InputFilePerExecution() {
+	signalFile := s.writeTempFile("signals.ndjson", `{"workflowId": "wid1", "name": "fix", "input": {"amount": 10}}
+
+{"workflowId": "wid2", "runId": "rid2", "input": [1, 2]}
+`)
+
+	s.frontendClient.EXPECT().SignalWorkflowExecution(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, request *workflowservice.SignalWorkflowExecutionRequest, _ ...interface{}) (*workflowservice.SignalWorkflowExecutionResponse, error) {
+			switch request.WorkflowExecution.GetWorkflowId() {
+			case "wid1":
+				s.Equal("fix", request.GetSignalName())
+			case "wid2":
+				s.Equal("default-name", request.GetSignalName())
+				s.Equal("rid2", request.WorkflowExecution.GetRunId())
+			default:
+				s.Fail("unexpected workflow id")
+			}
+			s.Len(request.GetInput().GetPayloads(), 1)
+			return &workflowservice.SignalWorkflowExecutionResponse{}, nil
+		}).Times(2)
+	err := s.app.Run([]string{"", "--namespace", cliTestNamespace, "workflow", "signal", "--name", "default-name", "--input-file-per-execution", signalFile, "--yes"})
+	s.NoError(err)
+}
+
+func (s *cliAppSuite) TestSignalWorkflow_InputFilePerExecution_RetriesTransientErrors() {
+	signalFile := s.writeTempFile("signals.ndjson", `{"workflowId": "wid1", "name": "fix"}`)
+
+	gomock.InOrder(
+		s.frontendClient.EXPECT().SignalWorkflowExecution(gomock.Any(), gomock.Any()).Return(nil, serviceerror.NewUnavailable("faked error")),
+		s.frontendClient.EXPECT().SignalWorkflowExecution(gomock.Any(), gomock.Any()).Return(&workflowservice.SignalWorkflowExecutionResponse{}, nil),
+	)
+	err := s.app.Run([]string{"", "--namespace", cliTestNamespace, "workflow", "signal", "--input-file-per-execution", signalFile, "--yes"})
+	s.NoError(err)
+}
+
+func (s *cliAppSuite) TestSignalWorkflow_InputFilePerExecution_Failed() {
+	signalFile := s.writeTempFile("signals.ndjson", `{"workflowId": "wid1", "name": "fix"}`)
+
+	s.frontendClient.EXPECT().SignalWorkflowExecution(gomock.Any(), gomock.Any()).Return(nil, serviceerror.NewNotFound("faked error")).Times(1)
+	errorCode := s.RunWithExitCode([]string{"", "--namespace", cliTestNamespace, "workflow", "signal", "--input-file-per-execution", signalFile, "--yes"})
+	s.Equal(1, errorCode)
+}
+
+func (s *cliAppSuite) TestSignalWorkflow_InputFilePerExecution_MissingName() {
+	signalFile := s.writeTempFile("signals.ndjson", `{"workflowId": "wid1"}`)
+
+	errorCode := s.RunWithExitCode([]string{"", "--namespace", cliTestNamespace, "workflow", "signal", "--input-file-per-execution", signalFile, "--yes"})
+	s.Equal(1, errorCode)
+}
+
+func (s *cliAppSuite) TestBenchWorkflow() {
+	hasBenchID := mock.MatchedBy(func(options sdkclient.StartWorkflowOptions) bool {
+		return strings.HasPrefix(options.ID, "bench-")
+	})
+	s.sdkClient.On("ExecuteWorkflow", mock.Anything, hasBenchID, "testWorkflowType", mock.Anything).Return(workflowRun(), nil).Times(5)
+
+	err := s.app.Run([]string{"", "--namespace", cliTestNamespace, "workflow", "bench", "--task-queue", "testTaskQueue", "--type", "testWorkflowType",
+		"--workflow-id", "bench", "--count", "5", "--concurrency", "2"})
+	s.Nil(err)
+	s.sdkClient.AssertExpectations(s.T())
+}
+
+func (s *cliAppSuite) TestBenchWorkflow_Wait() {
+	run := &sdkmocks.WorkflowRun{}
+	run.On("Get", mock.Anything, mock.Anything).Return(nil).Times(3)
+	s.sdkClient.On("ExecuteWorkflow", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(run, nil).Times(3)
+
+	err := s.app.Run([]string{"", "--namespace", cliTestNamespace, "workflow", "bench", "--task-queue", "testTaskQueue", "--type", "testWorkflowType",
+		"--count", "3", "--wait", "-o", "json"})
+	s.Nil(err)
+	s.sdkClient.AssertExpectations(s.T())
+	run.AssertExpectations(s.T())
+}
+
+func (s *cliAppSuite) TestBenchWorkflow_StartFailed() {
+	s.sdkClient.On("ExecuteWorkflow", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, serviceerror.NewInvalidArgument("bad request")).Times(2)
+
+	errorCode := s.RunWithExitCode([]string{"", "--namespace", cliTestNamespace, "workflow", "bench", "--task-queue", "testTaskQueue", "--type", "testWorkflowType",
+		"--count", "2"})
+	s.Equal(1, errorCode)
+}
+
+func (s *cliAppSuite) TestBenchWorkflow_WaitReleasesConcurrencySlot() {
+	// with a single slot, the first workflow completes only once the second one has been started
+	secondStarted := make(chan struct{})
+	var starts int32
+	run := &sdkmocks.WorkflowRun{}
+	run.On("Get", mock.Anything, mock.Anything).Run(func(mock.Arguments) {
+		select {
+		case <-secondStarted:
+		case <-time.After(5 * time.Second):
+			s.Fail("second workflow was not started while waiting for the first")
+		}
+	}).Return(nil).Times(2)
+	s.sdkClient.On("ExecuteWorkflow", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Run(func(mock.Arguments) {
+		if atomic.AddInt32(&starts, 1) == 2 {
+			close(secondStarted)
+		}
+	}).Return(run, nil).Times(2)
+
+	err := s.app.Run([]string{"", "--namespace", cliTestNamespace, "workflow", "bench", "--task-queue", "testTaskQueue", "--type", "testWorkflowType",
+		"--count", "2", "--concurrency", "1", "--wait"})
+	s.Nil(err)
+	run.AssertExpectations(s.T())
+}
+
+func (s *cliAppSuite) TestBenchWorkflow_Report() {
+	samples := []benchSample{{}, {}, {startErr: errors.New("bad request")}}
+	report := newBenchReport(samples, time.Second, 10*time.Second, true)
+	s.Equal(2, report.Started)
+	s.Equal(2, report.Completed)
+	// starts are measured without the time spent waiting for completion
+	s.Equal(2.0, report.StartsPerSecond)
+	s.Equal(0.2, report.CompletionsPerSecond)
+	s.Equal(time.Second, report.StartDuration)
+	s.Equal(10*time.Second, report.Duration)
+}
+
+func (s *cliAppSuite) TestBenchWorkflow_LatencyStats() {
+	var latencies []time.Duration
+	for i := 100; i > 0; i-- {
+		latencies = append(latencies, time.Duration(i)*time.Millisecond)
+	}
+
+	stats := newLatencyStats("start", latencies)
+	s.Equal(100, stats.Count)
+	s.Equal(time.Millisecond, stats.Min)
+	s.Equal(50*time.Millisecond, stats.P50)
+	s.Equal(90*time.Millisecond, stats.P90)
+	s.Equal(99*time.Millisecond, stats.P99)
+	s.Equal(100*time.Millisecond, stats.Max)
+	s.Equal(50500*time.Microsecond, stats.Mean)
+	s.Nil(newLatencyStats("start", nil))
+}
+
+func (s *cliAppSuite) TestExportWorkflow() {
+	dir := filepath.Join(s.T().TempDir(), "export")
+	history := &workflowservice.GetWorkflowExecutionHistoryResponse{
+		History: &historypb.History{Events: []*historypb.HistoryEvent{{EventId: 1}, {EventId: 2}}},
+	}
+	s.sdkClient.On("ListWorkflow", mock.Anything, mock.Anything).Return(listWorkflowExecutionsResponse, nil).Once()
+	s.frontendClient.EXPECT().GetWorkflowExecutionHistory(gomock.Any(), gomock.Any()).Return(history, nil).Times(2)
+
+	bundle := filepath.Join(s.T().TempDir(), "export.tar.gz")
+	err := s.app.Run([]string{"", "--namespace", cliTestNamespace, "workflow", "export", "--query", "ExecutionStatus='Completed'", "--dir", dir, "--bundle", bundle})
+	s.Nil(err)
+	s.sdkClient.AssertExpectations(s.T())
+
+	manifest, err := os.ReadFile(filepath.Join(dir, "manifest.ndjson"))
+	s.NoError(err)
+	lines := strings.Split(strings.TrimSpace(string(manifest)), "\n")
+	s.Len(lines, 2)
+	var entry exportManifestEntry
+	s.NoError(json.Unmarshal([]byte(lines[0]), &entry))
+	s.Equal(2, entry.Events)
+	_, err = os.Stat(filepath.Join(dir, entry.HistoryFile))
+	s.NoError(err)
+	_, err = os.Stat(bundle)
+	s.NoError(err)
+
+	// a second run resumes and does not download the histories again
+	s.sdkClient.On("ListWorkflow", mock.Anything, mock.Anything).Return(listWorkflowExecutionsResponse, nil).Once()
+	err = s.app.Run([]string{"", "--namespace", cliTestNamespace, "workflow", "export", "--dir", dir})
+	s.Nil(err)
+	s.sdkClient.AssertExpectations(s.T())
+}
+
+func (s *cliAppSuite) TestExportWorkflow_ResumeAfterPartialManifestLine() {
+	exported := listWorkflowExecutionsResponse.Executions[0].GetExecution()
+	line, err := json.Marshal(&exportManifestEntry{WorkflowID: exported.GetWorkflowId(), RunID: exported.GetRunId(), Execution: json.RawMessage("{}")})
+	s.NoError(err)
+	// the previous run was killed while writing the second line
+	manifestPath := s.writeTempFile("manifest.ndjson", string(line)+"\n"+`{"workflowId": "test-list-wor`)
+	dir := filepath.Dir(manifestPath)
+
+	history := &workflowservice.GetWorkflowExecutionHistoryResponse{History: &historypb.History{Events: []*historypb.HistoryEvent{{EventId: 1}}}}
+	s.sdkClient.On("ListWorkflow", mock.Anything, mock.Anything).Return(listWorkflowExecutionsResponse, nil).Once()
+	s.frontendClient.EXPECT().GetWorkflowExecutionHistory(gomock.Any(), gomock.Any()).Return(history, nil).Times(1)
+	err = s.app.Run([]string{"", "--namespace", cliTestNamespace, "workflow", "export", "--dir", dir})
+	s.Nil(err)
+
+	manifest, err := os.ReadFile(manifestPath)
+	s.NoError(err)
+	lines := strings.Split(strings.TrimSpace(string(manifest)), "\n")
+	s.Len(lines, 2)
+	for _, l := range lines {
+		var entry exportManifestEntry
+		s.NoError(json.Unmarshal([]byte(l), &entry))
+	}
+}
+
+func (s *cliAppSuite) TestExportWorkflow_LongWorkflowIDAndLeftoverTmpFile() {
+	dir := s.T().TempDir()
+	s.NoError(os.MkdirAll(filepath.Join(dir, "histories"), 0755))
+	s.NoError(os.WriteFile(filepath.Join(dir, "histories", "interrupted.json.tmp"), []byte("{"), 0644))
+
+	longID := strings.Repeat("order/", 100)
+	resp := &workflowservice.ListWorkflowExecutionsResponse{Executions: []*workflowpb.WorkflowExecutionInfo{{
+		Execution: &commonpb.WorkflowExecution{WorkflowId: longID, RunId: uuid.New()},
+	}}}
+	s.sdkClient.On("ListWorkflow", mock.Anything, mock.Anything).Return(resp, nil).Once()
+	s.frontendClient.EXPECT().GetWorkflowExecutionHistory(gomock.Any(), gomock.Any()).Return(&workflowservice.GetWorkflowExecutionHistoryResponse{
+		History: &historypb.History{Events: []*historypb.HistoryEvent{{EventId: 1}}},
+	}, nil)
+
+	bundle := filepath.Join(s.T().TempDir(), "export.tar.gz")
+	err := s.app.Run([]string{"", "--namespace", cliTestNamespace, "workflow", "export", "--dir", dir, "--bundle", bundle})
+	s.Nil(err)
+
+	manifest, err := os.ReadFile(filepath.Join(dir, "manifest.ndjson"))
+	s.NoError(err)
+	var entry exportManifestEntry
+	s.NoError(json.Unmarshal(manifest, &entry))
+	s.Equal(longID, entry.WorkflowID)
+	s.LessOrEqual(len(filepath.Base(entry.HistoryFile)+".tmp"), 255)
+	_, err = os.Stat(filepath.Join(dir, entry.HistoryFile))
+	s.NoError(err)
+
+	f, err := os.Open(bundle)
+	s.NoError(err)
+	defer f.Close()
+	gr, err := gzip.NewReader(f)
+	s.NoError(err)
+	tr := tar.NewReader(gr)
+	var names []string
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		s.NoError(err)
+		names = append(names, header.Name)
+	}
+	s.Contains(names, path.Join(filepath.Base(dir), entry.HistoryFile))
+	for _, name := range names {
+		s.False(strings.HasSuffix(name, ".tmp"), name)
+	}
+}
+
+func (s *cliAppSuite) TestExportWorkflow_Failed() {
+	dir := s.T().TempDir()
+	s.sdkClient.On("ListWorkflow", mock.Anything, mock.Anything).Return(listWorkflowExecutionsResponse, nil).Once()
+	s.frontendClient.EXPECT().GetWorkflowExecutionHistory(gomock.Any(), gomock.Any()).Return(nil, serviceerror.NewNotFound("not found")).Times(2)
+
+	errorCode := s.RunWithExitCode([]string{"", "--namespace", cliTestNamespace, "workflow", "export", "--dir", dir})
+	s.Equal(1, errorCode)
+
+	manifest, err := os.ReadFile(filepath.Join(dir, "manifest.ndjson"))
+	s.NoError(err)
+	s.Empty(manifest)
+}
+
+var archivedHistoryResponse = &workflowservice.GetWorkflowExecutionHistoryResponse{
+	History: &historypb.History{Events: []*historypb.HistoryEvent{
+		{
+			EventId:   1,
+			EventType: enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_STARTED,
+			Attributes: &historypb.HistoryEvent_WorkflowExecutionStartedEventAttributes{WorkflowExecutionStartedEventAttributes: &historypb.WorkflowExecutionStartedEventAttributes{
+				WorkflowType: &commonpb.WorkflowType{Name: "TestWorkflow"},
+				TaskQueue:    &taskqueuepb.TaskQueue{Name: "taskQueue"},
+			}},
+		},
+		{
+			EventId:   2,
+			EventType: enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_COMPLETED,
+			Attributes: &historypb.HistoryEvent_WorkflowExecutionCompletedEventAttributes{WorkflowExecutionCompletedEventAttributes: &historypb.WorkflowExecutionCompletedEventAttributes{
+				Result: payloads.EncodeString("result"),
+			}},
+		},
+	}},
+	Archived: true,
+}
+
+func archivalEnabledNamespace(state enumspb.ArchivalState) *workflowservice.DescribeNamespaceResponse {
+	return &workflowservice.DescribeNamespaceResponse{
+		Config: &namespacepb.NamespaceConfig{HistoryArchivalState: state},
+	}
+}
+
+func (s *cliAppSuite) TestShowHistory_Archived() {
+	s.frontendClient.EXPECT().DescribeNamespace(gomock.Any(), gomock.Any()).Return(archivalEnabledNamespace(enumspb.ARCHIVAL_STATE_ENABLED), nil)
+	s.frontendClient.EXPECT().GetWorkflowExecutionHistory(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, req *workflowservice.GetWorkflowExecutionHistoryRequest, _ ...interface{}) (*workflowservice.GetWorkflowExecutionHistoryResponse, error) {
+			s.False(req.SkipArchival)
+			s.Equal("rid", req.Execution.GetRunId())
+			return archivedHistoryResponse, nil
+		})
+	err := s.app.Run([]string{"", "--namespace", cliTestNamespace, "workflow", "show", "--workflow-id", "wid", "--run-id", "rid", "--archived"})
+	s.Nil(err)
+}
+
+func (s *cliAppSuite) TestShowHistory_ArchivalDisabled() {
+	s.frontendClient.EXPECT().DescribeNamespace(gomock.Any(), gomock.Any()).Return(archivalEnabledNamespace(enumspb.ARCHIVAL_STATE_DISABLED), nil)
+	errorCode := s.RunWithExitCode([]string{"", "--namespace", cliTestNamespace, "workflow", "show", "--workflow-id", "wid", "--run-id", "rid", "--archived"})
+	s.Equal(1, errorCode)
+}
+
+func (s *cliAppSuite) TestShowHistory_ArchivedRequiresRunID() {
+	errorCode := s.RunWithExitCode([]string{"", "--namespace", cliTestNamespace, "workflow", "show", "--workflow-id", "wid", "--archived"})
+	s.Equal(1, errorCode)
+	errorCode = s.RunWithExitCode([]string{"", "--namespace", cliTestNamespace, "workflow", "describe", "--workflow-id", "wid", "--archived"})
+	s.Equal(1, errorCode)
+}
+
+func (s *cliAppSuite) TestDescribeWorkflow_Archived() {
+	s.frontendClient.EXPECT().DescribeNamespace(gomock.Any(), gomock.Any()).Return(archivalEnabledNamespace(enumspb.ARCHIVAL_STATE_ENABLED), nil)
+	s.frontendClient.EXPECT().GetWorkflowExecutionHistory(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, req *workflowservice.GetWorkflowExecutionHistoryRequest, _ ...interface{}) (*workflowservice.GetWorkflowExecutionHistoryResponse, error) {
+			s.Equal("rid", req.Execution.GetRunId())
+			return archivedHistoryResponse, nil
+		})
+	err := s.app.Run([]string{"", "--namespace", cliTestNamespace, "workflow", "describe", "--workflow-id", "wid", "--run-id", "rid", "--archived"})
+	s.Nil(err)
+}
+
+func resetPreviewHistory() []*historypb.HistoryEvent {
+	return []*historypb.HistoryEvent{
+		{EventId: 1, EventType: enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_STARTED},
+		{EventId: 2, EventType: enumspb.EVENT_TYPE_WORKFLOW_TASK_SCHEDULED},
+		{EventId: 3, EventType: enumspb.EVENT_TYPE_WORKFLOW_TASK_STARTED},
+		{EventId: 4, EventType: enumspb.EVENT_TYPE_WORKFLOW_TASK_COMPLETED},
+		{EventId: 5, EventType: enumspb.EVENT_TYPE_ACTIVITY_TASK_SCHEDULED, Attributes: &historypb.HistoryEvent_ActivityTaskScheduledEventAttributes{
+			ActivityTaskScheduledEventAttributes: &historypb.ActivityTaskScheduledEventAttributes{ActivityId: "5", ActivityType: &commonpb.ActivityType{Name: "ChargeCard"}},
+		}},
+		{EventId: 6, EventType: enumspb.EVENT_TYPE_ACTIVITY_TASK_STARTED, Attributes: &historypb.HistoryEvent_ActivityTaskStartedEventAttributes{
+			ActivityTaskStartedEventAttributes: &historypb.ActivityTaskStartedEventAttributes{ScheduledEventId: 5},
+		}},
+		{EventId: 7, EventType: enumspb.EVENT_TYPE_ACTIVITY_TASK_COMPLETED, Attributes: &historypb.HistoryEvent_ActivityTaskCompletedEventAttributes{
+			ActivityTaskCompletedEventAttributes: &historypb.ActivityTaskCompletedEventAttributes{ScheduledEventId: 5},
+		}},
+		{EventId: 8, EventType: enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_SIGNALED, Attributes: &historypb.HistoryEvent_WorkflowExecutionSignaledEventAttributes{
+			WorkflowExecutionSignaledEventAttributes: &historypb.WorkflowExecutionSignaledEventAttributes{SignalName: "cancel-order"},
+		}},
+		{EventId: 9, EventType: enumspb.EVENT_TYPE_WORKFLOW_TASK_SCHEDULED},
+		{EventId: 10, EventType: enumspb.EVENT_TYPE_WORKFLOW_TASK_STARTED},
+		{EventId: 11, EventType: enumspb.EVENT_TYPE_WORKFLOW_TASK_COMPLETED},
+		{EventId: 12, EventType: enumspb.EVENT_TYPE_TIMER_STARTED, Attributes: &historypb.HistoryEvent_TimerStartedEventAttributes{
+			TimerStartedEventAttributes: &historypb.TimerStartedEventAttributes{TimerId: "12", StartToFireTimeout: timestamp.DurationPtr(time.Minute)},
+		}},
+	}
+}
+
+func (s *cliAppSuite) TestComputeResetPreview() {
+	preview, err := computeResetPreview(resetPreviewHistory(), 4, enumspb.RESET_REAPPLY_TYPE_SIGNAL)
+	s.NoError(err)
+	var discarded []int64
+	for _, e := range preview.Discarded {
+		discarded = append(discarded, e.ID)
+	}
+	s.Equal([]int64{5, 6, 7, 12}, discarded)
+	s.Len(preview.Reapplied, 1)
+	s.Equal("cancel-order", preview.Reapplied[0].Details)
+	s.Len(preview.Warnings, 1)
+	s.Contains(preview.Warnings[0], "ChargeCard")
+
+	preview, err = computeResetPreview(resetPreviewHistory(), 11, enumspb.RESET_REAPPLY_TYPE_NONE)
+	s.NoError(err)
+	s.Len(preview.Discarded, 1)
+	s.Empty(preview.Reapplied)
+	s.Empty(preview.Warnings)
+
+	preview, err = computeResetPreview(resetPreviewHistory(), 4, enumspb.RESET_REAPPLY_TYPE_NONE)
+	s.NoError(err)
+	s.Len(preview.Discarded, 5)
+	s.Empty(preview.Reapplied)
+
+	_, err = computeResetPreview(resetPreviewHistory(), 7, enumspb.RESET_REAPPLY_TYPE_SIGNAL)
+	s.Error(err)
+}
+
+func (s *cliAppSuite) TestResetWorkflow_Preview() {
+	resp := &workflowservice.GetWorkflowExecutionHistoryResponse{
+		History: &historypb.History{Events: resetPreviewHistory()},
+	}
+	s.frontendClient.EXPECT().GetWorkflowExecutionHistory(gomock.Any(), gomock.Any()).Return(resp, nil)
+	err := s.app.Run([]string{"", "--namespace", cliTestNamespace, "workflow", "reset", "--workflow-id", "wid", "--run-id", "rid", "--event-id", "4", "--preview"})
+	s.Nil(err)
+}
+
 func (s *cliAppSuite) TestQueryWorkflow() {
 	resp := &workflowservice.QueryWorkflowResponse{
 		QueryResult: payloads.EncodeString("query-result"),